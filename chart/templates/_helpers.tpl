@@ -60,6 +60,10 @@ app.kubernetes.io/instance: {{ .Release.Name }}
 {{- .Values.config.database.existingSecret | default (printf "%s-database" (include "gopad-api.fullname" .)) -}}
 {{- end -}}
 
+{{- define "gopad-api.upload.keysSecretName" -}}
+{{- .Values.config.upload.existingKeysSecret | default (printf "%s-upload-keys" (include "gopad-api.fullname" .)) -}}
+{{- end -}}
+
 {{- define "gopad-api.token.secretName" -}}
 {{- .Values.config.token.existingSecret | default (printf "%s-token" (include "gopad-api.fullname" .)) -}}
 {{- end -}}
@@ -72,6 +76,10 @@ app.kubernetes.io/instance: {{ .Release.Name }}
 {{- .Values.config.scim.existingSecret | default (printf "%s-scim" (include "gopad-api.fullname" .)) -}}
 {{- end -}}
 
+{{- define "gopad-api.mail.secretName" -}}
+{{- .Values.config.mail.existingSecret | default (printf "%s-mail" (include "gopad-api.fullname" .)) -}}
+{{- end -}}
+
 {{- define "gopad-api.shared.environment" -}}
 - name: GOPAD_API_LOG_LEVEL
   value: "{{ .Values.config.log.level }}"
@@ -100,7 +108,13 @@ app.kubernetes.io/instance: {{ .Release.Name }}
     secretKeyRef:
       name: "{{ include "gopad-api.database.secretName" . }}"
       key: "{{ .Values.config.database.passwordKey }}"
+{{- with .Values.config.database.replicas }}
+- name: GOPAD_API_DATABASE_REPLICAS
+  value: "{{ join "," . }}"
 {{- end }}
+{{- end }}
+- name: GOPAD_API_DATABASE_MIGRATE
+  value: "{{ .Values.config.database.migrate }}"
 {{- if eq .Values.config.upload.driver "file" }}
 - name: GOPAD_API_UPLOAD_DRIVER
   value: "{{ .Values.config.upload.driver }}"
@@ -137,6 +151,54 @@ app.kubernetes.io/instance: {{ .Release.Name }}
 - name: GOPAD_API_UPLOAD_PERMS
   value: "{{ .Values.config.upload.perms }}"
 {{- end }}
+{{- if eq .Values.config.upload.driver "azure" }}
+- name: GOPAD_API_UPLOAD_DRIVER
+  value: "{{ .Values.config.upload.driver }}"
+- name: GOPAD_API_UPLOAD_ENDPOINT
+  value: "{{ .Values.config.upload.endpoint }}"
+- name: GOPAD_API_UPLOAD_BUCKET
+  value: "{{ .Values.config.upload.bucket }}"
+- name: GOPAD_API_UPLOAD_PATH
+  value: "{{ .Values.config.upload.path }}"
+- name: GOPAD_API_UPLOAD_ACCESS
+  valueFrom:
+    secretKeyRef:
+      name: "{{ include "gopad-api.upload.secretName" . }}"
+      key: "{{ .Values.config.upload.accessKey }}"
+- name: GOPAD_API_UPLOAD_SECRET
+  valueFrom:
+    secretKeyRef:
+      name: "{{ include "gopad-api.upload.secretName" . }}"
+      key: "{{ .Values.config.upload.secretKey }}"
+- name: GOPAD_API_UPLOAD_PROXY
+  value: "{{ .Values.config.upload.proxy }}"
+{{- end }}
+{{- if eq .Values.config.upload.driver "gcs" }}
+- name: GOPAD_API_UPLOAD_DRIVER
+  value: "{{ .Values.config.upload.driver }}"
+- name: GOPAD_API_UPLOAD_ENDPOINT
+  value: "{{ .Values.config.upload.endpoint }}"
+- name: GOPAD_API_UPLOAD_BUCKET
+  value: "{{ .Values.config.upload.bucket }}"
+- name: GOPAD_API_UPLOAD_PATH
+  value: "{{ .Values.config.upload.path }}"
+- name: GOPAD_API_UPLOAD_SECRET
+  valueFrom:
+    secretKeyRef:
+      name: "{{ include "gopad-api.upload.secretName" . }}"
+      key: "{{ .Values.config.upload.secretKey }}"
+- name: GOPAD_API_UPLOAD_PROXY
+  value: "{{ .Values.config.upload.proxy }}"
+{{- end }}
+{{- if or .Values.config.upload.keys .Values.config.upload.existingKeysSecret }}
+- name: GOPAD_API_UPLOAD_KEYS
+  valueFrom:
+    secretKeyRef:
+      name: "{{ include "gopad-api.upload.keysSecretName" . }}"
+      key: "{{ .Values.config.upload.keysKey }}"
+- name: GOPAD_API_UPLOAD_PLAINTEXT
+  value: "{{ .Values.config.upload.plaintext }}"
+{{- end }}
 {{- end -}}
 
 {{- define "gopad-api.server.environment" -}}
@@ -147,6 +209,42 @@ app.kubernetes.io/instance: {{ .Release.Name }}
   value: "{{ .Values.config.server.root }}"
 - name: GOPAD_API_SERVER_DOCS
   value: "{{ .Values.config.server.docs }}"
+- name: GOPAD_API_AVATAR_GRAVATAR
+  value: "{{ .Values.config.avatar.gravatar }}"
+- name: GOPAD_API_AVATAR_SIZE
+  value: "{{ .Values.config.avatar.size }}"
+- name: GOPAD_API_AVATAR_LIMIT
+  value: "{{ .Values.config.avatar.limit | int64 }}"
+- name: GOPAD_API_QUOTA_USER_BYTES
+  value: "{{ .Values.config.quota.user.bytes | int64 }}"
+- name: GOPAD_API_QUOTA_USER_OBJECTS
+  value: "{{ .Values.config.quota.user.objects | int64 }}"
+- name: GOPAD_API_QUOTA_GROUP_BYTES
+  value: "{{ .Values.config.quota.group.bytes | int64 }}"
+- name: GOPAD_API_QUOTA_GROUP_OBJECTS
+  value: "{{ .Values.config.quota.group.objects | int64 }}"
+- name: GOPAD_API_DIGEST_ENABLED
+  value: "{{ .Values.config.digest.enabled }}"
+{{- if .Values.config.digest.enabled }}
+- name: GOPAD_API_DIGEST_INTERVAL
+  value: "{{ .Values.config.digest.interval }}"
+- name: GOPAD_API_MAIL_HOST
+  value: "{{ .Values.config.mail.host }}"
+- name: GOPAD_API_MAIL_PORT
+  value: "{{ .Values.config.mail.port }}"
+- name: GOPAD_API_MAIL_FROM
+  value: "{{ .Values.config.mail.from }}"
+- name: GOPAD_API_MAIL_USERNAME
+  valueFrom:
+    secretKeyRef:
+      name: "{{ include "gopad-api.mail.secretName" . }}"
+      key: "{{ .Values.config.mail.usernameKey }}"
+- name: GOPAD_API_MAIL_PASSWORD
+  valueFrom:
+    secretKeyRef:
+      name: "{{ include "gopad-api.mail.secretName" . }}"
+      key: "{{ .Values.config.mail.passwordKey }}"
+{{- end }}
 - name: GOPAD_API_TOKEN_EXPIRE
   value: "{{ .Values.config.token.expire }}"
 - name: GOPAD_API_TOKEN_SECRET
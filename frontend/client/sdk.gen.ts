@@ -17,18 +17,36 @@ import type {
   AttachUserToGroupResponses,
   CallbackProviderData,
   CallbackProviderErrors,
+  CreateGroupAvatarData,
+  CreateGroupAvatarErrors,
+  CreateGroupAvatarResponses,
   CreateGroupData,
   CreateGroupErrors,
   CreateGroupResponses,
+  CreateProfileAvatarData,
+  CreateProfileAvatarErrors,
+  CreateProfileAvatarResponses,
+  CreateUserAvatarData,
+  CreateUserAvatarErrors,
+  CreateUserAvatarResponses,
   CreateUserData,
   CreateUserErrors,
   CreateUserResponses,
+  DeleteGroupAvatarData,
+  DeleteGroupAvatarErrors,
+  DeleteGroupAvatarResponses,
   DeleteGroupData,
   DeleteGroupErrors,
   DeleteGroupFromUserData,
   DeleteGroupFromUserErrors,
   DeleteGroupFromUserResponses,
   DeleteGroupResponses,
+  DeleteProfileAvatarData,
+  DeleteProfileAvatarErrors,
+  DeleteProfileAvatarResponses,
+  DeleteUserAvatarData,
+  DeleteUserAvatarErrors,
+  DeleteUserAvatarResponses,
   DeleteUserData,
   DeleteUserErrors,
   DeleteUserFromGroupData,
@@ -41,6 +59,9 @@ import type {
   ListGroupUsersData,
   ListGroupUsersErrors,
   ListGroupUsersResponses,
+  ListProfileNotificationsData,
+  ListProfileNotificationsErrors,
+  ListProfileNotificationsResponses,
   ListProvidersData,
   ListProvidersResponses,
   ListUserGroupsData,
@@ -58,6 +79,12 @@ import type {
   PermitUserGroupData,
   PermitUserGroupErrors,
   PermitUserGroupResponses,
+  ReadAllProfileNotificationsData,
+  ReadAllProfileNotificationsErrors,
+  ReadAllProfileNotificationsResponses,
+  ReadProfileNotificationData,
+  ReadProfileNotificationErrors,
+  ReadProfileNotificationResponses,
   RedirectAuthData,
   RedirectAuthErrors,
   RedirectAuthResponses,
@@ -72,12 +99,18 @@ import type {
   ShowProfileData,
   ShowProfileErrors,
   ShowProfileResponses,
+  ShowUsageData,
+  ShowUsageErrors,
+  ShowUsageResponses,
   ShowUserData,
   ShowUserErrors,
   ShowUserResponses,
   TokenProfileData,
   TokenProfileErrors,
   TokenProfileResponses,
+  UnreadProfileNotificationsData,
+  UnreadProfileNotificationsErrors,
+  UnreadProfileNotificationsResponses,
   UpdateGroupData,
   UpdateGroupErrors,
   UpdateGroupResponses,
@@ -285,6 +318,150 @@ export const updateProfile = <ThrowOnError extends boolean = false>(
     },
   })
 
+/**
+ * Upload an avatar for your own profile
+ */
+export const createProfileAvatar = <ThrowOnError extends boolean = false>(
+  options: Options<CreateProfileAvatarData, ThrowOnError>
+): RequestResult<CreateProfileAvatarResponses, CreateProfileAvatarErrors, ThrowOnError> =>
+  (options.client ?? client).post<
+    CreateProfileAvatarResponses,
+    CreateProfileAvatarErrors,
+    ThrowOnError
+  >({
+    security: [
+      { name: 'X-API-Key', type: 'apiKey' },
+      { scheme: 'bearer', type: 'http' },
+      { scheme: 'basic', type: 'http' },
+    ],
+    url: '/profile/avatar',
+    ...options,
+    headers: {
+      'Content-Type': 'image/png',
+      ...options.headers,
+    },
+  })
+
+/**
+ * Delete the avatar of your own profile
+ */
+export const deleteProfileAvatar = <ThrowOnError extends boolean = false>(
+  options: Options<DeleteProfileAvatarData, ThrowOnError>
+): RequestResult<DeleteProfileAvatarResponses, DeleteProfileAvatarErrors, ThrowOnError> =>
+  (options.client ?? client).delete<
+    DeleteProfileAvatarResponses,
+    DeleteProfileAvatarErrors,
+    ThrowOnError
+  >({
+    security: [
+      { name: 'X-API-Key', type: 'apiKey' },
+      { scheme: 'bearer', type: 'http' },
+      { scheme: 'basic', type: 'http' },
+    ],
+    url: '/profile/avatar',
+    ...options,
+  })
+
+/**
+ * Fetch the notifications of your own profile
+ */
+export const listProfileNotifications = <ThrowOnError extends boolean = false>(
+  options?: Options<ListProfileNotificationsData, ThrowOnError>
+): RequestResult<
+  ListProfileNotificationsResponses,
+  ListProfileNotificationsErrors,
+  ThrowOnError
+> =>
+  (options?.client ?? client).get<
+    ListProfileNotificationsResponses,
+    ListProfileNotificationsErrors,
+    ThrowOnError
+  >({
+    security: [
+      { name: 'X-API-Key', type: 'apiKey' },
+      { scheme: 'bearer', type: 'http' },
+      { scheme: 'basic', type: 'http' },
+    ],
+    url: '/profile/notifications',
+    ...options,
+  })
+
+/**
+ * Fetch the unread notification count of your own profile
+ */
+export const unreadProfileNotifications = <
+  ThrowOnError extends boolean = false,
+>(
+  options?: Options<UnreadProfileNotificationsData, ThrowOnError>
+): RequestResult<
+  UnreadProfileNotificationsResponses,
+  UnreadProfileNotificationsErrors,
+  ThrowOnError
+> =>
+  (options?.client ?? client).get<
+    UnreadProfileNotificationsResponses,
+    UnreadProfileNotificationsErrors,
+    ThrowOnError
+  >({
+    security: [
+      { name: 'X-API-Key', type: 'apiKey' },
+      { scheme: 'bearer', type: 'http' },
+      { scheme: 'basic', type: 'http' },
+    ],
+    url: '/profile/notifications/unread',
+    ...options,
+  })
+
+/**
+ * Mark all notifications of your own profile as read
+ */
+export const readAllProfileNotifications = <
+  ThrowOnError extends boolean = false,
+>(
+  options?: Options<ReadAllProfileNotificationsData, ThrowOnError>
+): RequestResult<
+  ReadAllProfileNotificationsResponses,
+  ReadAllProfileNotificationsErrors,
+  ThrowOnError
+> =>
+  (options?.client ?? client).post<
+    ReadAllProfileNotificationsResponses,
+    ReadAllProfileNotificationsErrors,
+    ThrowOnError
+  >({
+    security: [
+      { name: 'X-API-Key', type: 'apiKey' },
+      { scheme: 'bearer', type: 'http' },
+      { scheme: 'basic', type: 'http' },
+    ],
+    url: '/profile/notifications/read',
+    ...options,
+  })
+
+/**
+ * Mark a notification of your own profile as read
+ */
+export const readProfileNotification = <ThrowOnError extends boolean = false>(
+  options: Options<ReadProfileNotificationData, ThrowOnError>
+): RequestResult<
+  ReadProfileNotificationResponses,
+  ReadProfileNotificationErrors,
+  ThrowOnError
+> =>
+  (options.client ?? client).post<
+    ReadProfileNotificationResponses,
+    ReadProfileNotificationErrors,
+    ThrowOnError
+  >({
+    security: [
+      { name: 'X-API-Key', type: 'apiKey' },
+      { scheme: 'bearer', type: 'http' },
+      { scheme: 'basic', type: 'http' },
+    ],
+    url: '/profile/notifications/{notification_id}/read',
+    ...options,
+  })
+
 /**
  * Fetch all available groups
  */
@@ -393,6 +570,50 @@ export const updateGroup = <ThrowOnError extends boolean = false>(
     },
   })
 
+/**
+ * Upload an avatar for a specific group
+ */
+export const createGroupAvatar = <ThrowOnError extends boolean = false>(
+  options: Options<CreateGroupAvatarData, ThrowOnError>
+): RequestResult<CreateGroupAvatarResponses, CreateGroupAvatarErrors, ThrowOnError> =>
+  (options.client ?? client).post<
+    CreateGroupAvatarResponses,
+    CreateGroupAvatarErrors,
+    ThrowOnError
+  >({
+    security: [
+      { name: 'X-API-Key', type: 'apiKey' },
+      { scheme: 'bearer', type: 'http' },
+      { scheme: 'basic', type: 'http' },
+    ],
+    url: '/groups/{group_id}/avatar',
+    ...options,
+    headers: {
+      'Content-Type': 'image/png',
+      ...options.headers,
+    },
+  })
+
+/**
+ * Delete the avatar of a specific group
+ */
+export const deleteGroupAvatar = <ThrowOnError extends boolean = false>(
+  options: Options<DeleteGroupAvatarData, ThrowOnError>
+): RequestResult<DeleteGroupAvatarResponses, DeleteGroupAvatarErrors, ThrowOnError> =>
+  (options.client ?? client).delete<
+    DeleteGroupAvatarResponses,
+    DeleteGroupAvatarErrors,
+    ThrowOnError
+  >({
+    security: [
+      { name: 'X-API-Key', type: 'apiKey' },
+      { scheme: 'bearer', type: 'http' },
+      { scheme: 'basic', type: 'http' },
+    ],
+    url: '/groups/{group_id}/avatar',
+    ...options,
+  })
+
 /**
  * Unlink a user from group
  */
@@ -605,6 +826,50 @@ export const updateUser = <ThrowOnError extends boolean = false>(
     },
   })
 
+/**
+ * Upload an avatar for a specific user
+ */
+export const createUserAvatar = <ThrowOnError extends boolean = false>(
+  options: Options<CreateUserAvatarData, ThrowOnError>
+): RequestResult<CreateUserAvatarResponses, CreateUserAvatarErrors, ThrowOnError> =>
+  (options.client ?? client).post<
+    CreateUserAvatarResponses,
+    CreateUserAvatarErrors,
+    ThrowOnError
+  >({
+    security: [
+      { name: 'X-API-Key', type: 'apiKey' },
+      { scheme: 'bearer', type: 'http' },
+      { scheme: 'basic', type: 'http' },
+    ],
+    url: '/users/{user_id}/avatar',
+    ...options,
+    headers: {
+      'Content-Type': 'image/png',
+      ...options.headers,
+    },
+  })
+
+/**
+ * Delete the avatar of a specific user
+ */
+export const deleteUserAvatar = <ThrowOnError extends boolean = false>(
+  options: Options<DeleteUserAvatarData, ThrowOnError>
+): RequestResult<DeleteUserAvatarResponses, DeleteUserAvatarErrors, ThrowOnError> =>
+  (options.client ?? client).delete<
+    DeleteUserAvatarResponses,
+    DeleteUserAvatarErrors,
+    ThrowOnError
+  >({
+    security: [
+      { name: 'X-API-Key', type: 'apiKey' },
+      { scheme: 'bearer', type: 'http' },
+      { scheme: 'basic', type: 'http' },
+    ],
+    url: '/users/{user_id}/avatar',
+    ...options,
+  })
+
 /**
  * Unlink a group from user
  */
@@ -708,3 +973,23 @@ export const permitUserGroup = <ThrowOnError extends boolean = false>(
       ...options.headers,
     },
   })
+
+/**
+ * Fetch the storage usage of users and groups
+ */
+export const showUsage = <ThrowOnError extends boolean = false>(
+  options?: Options<ShowUsageData, ThrowOnError>
+): RequestResult<ShowUsageResponses, ShowUsageErrors, ThrowOnError> =>
+  (options?.client ?? client).get<
+    ShowUsageResponses,
+    ShowUsageErrors,
+    ThrowOnError
+  >({
+    security: [
+      { name: 'X-API-Key', type: 'apiKey' },
+      { scheme: 'bearer', type: 'http' },
+      { scheme: 'basic', type: 'http' },
+    ],
+    url: '/usage',
+    ...options,
+  })
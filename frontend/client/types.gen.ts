@@ -26,6 +26,7 @@ export type Profile = {
   email?: string
   fullname?: string
   profile?: string
+  digest?: boolean
   readonly admin?: boolean
   readonly active?: boolean
   readonly created_at?: string
@@ -34,6 +35,21 @@ export type Profile = {
   readonly groups?: Array<UserGroup>
 }
 
+/**
+ * Profile Notification
+ *
+ * Model to represent profile notification
+ */
+export type ProfileNotification = {
+  readonly id?: string
+  readonly kind?: string
+  readonly reference?: string
+  readonly message?: string
+  readonly read_at?: string
+  readonly created_at?: string
+  readonly updated_at?: string
+}
+
 /**
  * Group
  *
@@ -43,6 +59,7 @@ export type Group = {
   id?: string
   slug?: string
   name?: string
+  readonly profile?: string
   readonly created_at?: string
   readonly updated_at?: string
 }
@@ -105,6 +122,20 @@ export type Provider = {
   readonly icon?: string
 }
 
+/**
+ * Usage
+ *
+ * Storage usage of an user or group
+ */
+export type Usage = {
+  readonly id?: string
+  readonly name?: string
+  readonly bytes?: number
+  readonly objects?: number
+  readonly max_bytes?: number
+  readonly max_objects?: number
+}
+
 /**
  * Notification
  *
@@ -138,6 +169,7 @@ export type ProfileWritable = {
   email?: string
   fullname?: string
   profile?: string
+  digest?: boolean
 }
 
 /**
@@ -203,6 +235,16 @@ export type PagingLimitParam = number
  */
 export type PagingOffsetParam = number
 
+/**
+ * Paging cursor, takes precedence over the offset
+ */
+export type PagingCursorParam = string
+
+/**
+ * Count the total of all matching records
+ */
+export type PagingCountParam = boolean
+
 /**
  * Sorting column
  */
@@ -228,6 +270,16 @@ export type AuthStateParam = string
  */
 export type AuthCodeParam = string
 
+/**
+ * A notification identifier
+ */
+export type NotificationParam = string
+
+/**
+ * Only list unread notifications
+ */
+export type NotificationUnreadParam = boolean
+
 /**
  * A group identifier or slug
  */
@@ -261,6 +313,7 @@ export type UpdateProfileBody = {
   password?: string
   email?: string
   fullname?: string
+  digest?: boolean
 }
 
 /**
@@ -651,6 +704,250 @@ export type UpdateProfileResponses = {
 export type UpdateProfileResponse =
   UpdateProfileResponses[keyof UpdateProfileResponses]
 
+export type CreateProfileAvatarData = {
+  /**
+   * The avatar image to upload
+   */
+  body: Blob | File
+  path?: never
+  query?: never
+  url: '/profile/avatar'
+}
+
+export type CreateProfileAvatarErrors = {
+  /**
+   * Failed to parse request
+   */
+  400: Notification
+  /**
+   * User is not authorized
+   */
+  403: Notification
+  /**
+   * Request body is too large
+   */
+  413: Notification
+  /**
+   * Some internal server error
+   */
+  500: Notification
+}
+
+export type CreateProfileAvatarError =
+  CreateProfileAvatarErrors[keyof CreateProfileAvatarErrors]
+
+export type CreateProfileAvatarResponses = {
+  /**
+   * The current profile details
+   */
+  200: Profile
+}
+
+export type CreateProfileAvatarResponse =
+  CreateProfileAvatarResponses[keyof CreateProfileAvatarResponses]
+
+export type DeleteProfileAvatarData = {
+  body?: never
+  path?: never
+  query?: never
+  url: '/profile/avatar'
+}
+
+export type DeleteProfileAvatarErrors = {
+  /**
+   * User is not authorized
+   */
+  403: Notification
+  /**
+   * Some internal server error
+   */
+  500: Notification
+}
+
+export type DeleteProfileAvatarError =
+  DeleteProfileAvatarErrors[keyof DeleteProfileAvatarErrors]
+
+export type DeleteProfileAvatarResponses = {
+  /**
+   * The current profile details
+   */
+  200: Profile
+}
+
+export type DeleteProfileAvatarResponse =
+  DeleteProfileAvatarResponses[keyof DeleteProfileAvatarResponses]
+
+export type ListProfileNotificationsData = {
+  body?: never
+  path?: never
+  query?: {
+    /**
+     * Only list unread notifications
+     */
+    unread?: boolean
+    /**
+     * Sorting column
+     */
+    sort?: string
+    /**
+     * Sorting order
+     */
+    order?: 'asc' | 'desc'
+    /**
+     * Paging limit
+     */
+    limit?: number
+    /**
+     * Paging offset
+     */
+    offset?: number
+    /**
+     * Paging cursor, takes precedence over the offset
+     */
+    cursor?: string
+    /**
+     * Count the total of all matching records
+     */
+    count?: boolean
+  }
+  url: '/profile/notifications'
+}
+
+export type ListProfileNotificationsErrors = {
+  /**
+   * User is not authorized
+   */
+  403: Notification
+  /**
+   * Some internal server error
+   */
+  500: Notification
+}
+
+export type ListProfileNotificationsError =
+  ListProfileNotificationsErrors[keyof ListProfileNotificationsErrors]
+
+export type ListProfileNotificationsResponses = {
+  /**
+   * A collection of profile notifications
+   */
+  200: {
+    total?: number
+    limit: number
+    offset: number
+    next?: string
+    prev?: string
+    notifications: Array<ProfileNotification>
+  }
+}
+
+export type ListProfileNotificationsResponse =
+  ListProfileNotificationsResponses[keyof ListProfileNotificationsResponses]
+
+export type UnreadProfileNotificationsData = {
+  body?: never
+  path?: never
+  query?: never
+  url: '/profile/notifications/unread'
+}
+
+export type UnreadProfileNotificationsErrors = {
+  /**
+   * User is not authorized
+   */
+  403: Notification
+  /**
+   * Some internal server error
+   */
+  500: Notification
+}
+
+export type UnreadProfileNotificationsError =
+  UnreadProfileNotificationsErrors[keyof UnreadProfileNotificationsErrors]
+
+export type UnreadProfileNotificationsResponses = {
+  /**
+   * The unread notification count
+   */
+  200: {
+    unread: number
+  }
+}
+
+export type UnreadProfileNotificationsResponse =
+  UnreadProfileNotificationsResponses[keyof UnreadProfileNotificationsResponses]
+
+export type ReadAllProfileNotificationsData = {
+  body?: never
+  path?: never
+  query?: never
+  url: '/profile/notifications/read'
+}
+
+export type ReadAllProfileNotificationsErrors = {
+  /**
+   * User is not authorized
+   */
+  403: Notification
+  /**
+   * Some internal server error
+   */
+  500: Notification
+}
+
+export type ReadAllProfileNotificationsError =
+  ReadAllProfileNotificationsErrors[keyof ReadAllProfileNotificationsErrors]
+
+export type ReadAllProfileNotificationsResponses = {
+  /**
+   * Plain success message
+   */
+  200: Notification
+}
+
+export type ReadAllProfileNotificationsResponse =
+  ReadAllProfileNotificationsResponses[keyof ReadAllProfileNotificationsResponses]
+
+export type ReadProfileNotificationData = {
+  body?: never
+  path: {
+    /**
+     * A notification identifier
+     */
+    notification_id: string
+  }
+  query?: never
+  url: '/profile/notifications/{notification_id}/read'
+}
+
+export type ReadProfileNotificationErrors = {
+  /**
+   * User is not authorized
+   */
+  403: Notification
+  /**
+   * Resource not found
+   */
+  404: Notification
+  /**
+   * Some internal server error
+   */
+  500: Notification
+}
+
+export type ReadProfileNotificationError =
+  ReadProfileNotificationErrors[keyof ReadProfileNotificationErrors]
+
+export type ReadProfileNotificationResponses = {
+  /**
+   * The details for a profile notification
+   */
+  200: ProfileNotification
+}
+
+export type ReadProfileNotificationResponse =
+  ReadProfileNotificationResponses[keyof ReadProfileNotificationResponses]
+
 export type ListGroupsData = {
   body?: never
   path?: never
@@ -675,6 +972,14 @@ export type ListGroupsData = {
      * Paging offset
      */
     offset?: number
+    /**
+     * Paging cursor, takes precedence over the offset
+     */
+    cursor?: string
+    /**
+     * Count the total of all matching records
+     */
+    count?: boolean
   }
   url: '/groups'
 }
@@ -697,9 +1002,11 @@ export type ListGroupsResponses = {
    * A collection of groups
    */
   200: {
-    total: number
+    total?: number
     limit: number
     offset: number
+    next?: string
+    prev?: string
     groups: Array<Group>
   }
 }
@@ -878,6 +1185,97 @@ export type UpdateGroupResponses = {
 export type UpdateGroupResponse =
   UpdateGroupResponses[keyof UpdateGroupResponses]
 
+export type CreateGroupAvatarData = {
+  /**
+   * The avatar image to upload
+   */
+  body: Blob | File
+  path: {
+    /**
+     * A group identifier or slug
+     */
+    group_id: string
+  }
+  query?: never
+  url: '/groups/{group_id}/avatar'
+}
+
+export type CreateGroupAvatarErrors = {
+  /**
+   * Failed to parse request
+   */
+  400: Notification
+  /**
+   * User is not authorized
+   */
+  403: Notification
+  /**
+   * Resource not found
+   */
+  404: Notification
+  /**
+   * Request body is too large
+   */
+  413: Notification
+  /**
+   * Some internal server error
+   */
+  500: Notification
+}
+
+export type CreateGroupAvatarError =
+  CreateGroupAvatarErrors[keyof CreateGroupAvatarErrors]
+
+export type CreateGroupAvatarResponses = {
+  /**
+   * The details for a group
+   */
+  200: Group
+}
+
+export type CreateGroupAvatarResponse =
+  CreateGroupAvatarResponses[keyof CreateGroupAvatarResponses]
+
+export type DeleteGroupAvatarData = {
+  body?: never
+  path: {
+    /**
+     * A group identifier or slug
+     */
+    group_id: string
+  }
+  query?: never
+  url: '/groups/{group_id}/avatar'
+}
+
+export type DeleteGroupAvatarErrors = {
+  /**
+   * User is not authorized
+   */
+  403: Notification
+  /**
+   * Resource not found
+   */
+  404: Notification
+  /**
+   * Some internal server error
+   */
+  500: Notification
+}
+
+export type DeleteGroupAvatarError =
+  DeleteGroupAvatarErrors[keyof DeleteGroupAvatarErrors]
+
+export type DeleteGroupAvatarResponses = {
+  /**
+   * The details for a group
+   */
+  200: Group
+}
+
+export type DeleteGroupAvatarResponse =
+  DeleteGroupAvatarResponses[keyof DeleteGroupAvatarResponses]
+
 export type DeleteGroupFromUserData = {
   /**
    * The group user data to unlink
@@ -958,6 +1356,14 @@ export type ListGroupUsersData = {
      * Paging offset
      */
     offset?: number
+    /**
+     * Paging cursor, takes precedence over the offset
+     */
+    cursor?: string
+    /**
+     * Count the total of all matching records
+     */
+    count?: boolean
   }
   url: '/groups/{group_id}/users'
 }
@@ -985,9 +1391,11 @@ export type ListGroupUsersResponses = {
    * A collection of group users
    */
   200: {
-    total: number
+    total?: number
     limit: number
     offset: number
+    next?: string
+    prev?: string
     group?: Group
     users: Array<UserGroup>
   }
@@ -1130,6 +1538,14 @@ export type ListUsersData = {
      * Paging offset
      */
     offset?: number
+    /**
+     * Paging cursor, takes precedence over the offset
+     */
+    cursor?: string
+    /**
+     * Count the total of all matching records
+     */
+    count?: boolean
   }
   url: '/users'
 }
@@ -1152,9 +1568,11 @@ export type ListUsersResponses = {
    * A collection of users
    */
   200: {
-    total: number
+    total?: number
     limit: number
     offset: number
+    next?: string
+    prev?: string
     users: Array<User>
   }
 }
@@ -1381,6 +1799,97 @@ export type DeleteUserFromGroupResponses = {
 export type DeleteUserFromGroupResponse =
   DeleteUserFromGroupResponses[keyof DeleteUserFromGroupResponses]
 
+export type CreateUserAvatarData = {
+  /**
+   * The avatar image to upload
+   */
+  body: Blob | File
+  path: {
+    /**
+     * A user identifier or slug
+     */
+    user_id: string
+  }
+  query?: never
+  url: '/users/{user_id}/avatar'
+}
+
+export type CreateUserAvatarErrors = {
+  /**
+   * Failed to parse request
+   */
+  400: Notification
+  /**
+   * User is not authorized
+   */
+  403: Notification
+  /**
+   * Resource not found
+   */
+  404: Notification
+  /**
+   * Request body is too large
+   */
+  413: Notification
+  /**
+   * Some internal server error
+   */
+  500: Notification
+}
+
+export type CreateUserAvatarError =
+  CreateUserAvatarErrors[keyof CreateUserAvatarErrors]
+
+export type CreateUserAvatarResponses = {
+  /**
+   * The details for an user
+   */
+  200: User
+}
+
+export type CreateUserAvatarResponse =
+  CreateUserAvatarResponses[keyof CreateUserAvatarResponses]
+
+export type DeleteUserAvatarData = {
+  body?: never
+  path: {
+    /**
+     * A user identifier or slug
+     */
+    user_id: string
+  }
+  query?: never
+  url: '/users/{user_id}/avatar'
+}
+
+export type DeleteUserAvatarErrors = {
+  /**
+   * User is not authorized
+   */
+  403: Notification
+  /**
+   * Resource not found
+   */
+  404: Notification
+  /**
+   * Some internal server error
+   */
+  500: Notification
+}
+
+export type DeleteUserAvatarError =
+  DeleteUserAvatarErrors[keyof DeleteUserAvatarErrors]
+
+export type DeleteUserAvatarResponses = {
+  /**
+   * The details for an user
+   */
+  200: User
+}
+
+export type DeleteUserAvatarResponse =
+  DeleteUserAvatarResponses[keyof DeleteUserAvatarResponses]
+
 export type ListUserGroupsData = {
   body?: never
   path: {
@@ -1410,6 +1919,14 @@ export type ListUserGroupsData = {
      * Paging offset
      */
     offset?: number
+    /**
+     * Paging cursor, takes precedence over the offset
+     */
+    cursor?: string
+    /**
+     * Count the total of all matching records
+     */
+    count?: boolean
   }
   url: '/users/{user_id}/groups'
 }
@@ -1437,9 +1954,11 @@ export type ListUserGroupsResponses = {
    * A collection of user groups
    */
   200: {
-    total: number
+    total?: number
     limit: number
     offset: number
+    next?: string
+    prev?: string
     user?: User
     groups: Array<UserGroup>
   }
@@ -1557,3 +2076,35 @@ export type PermitUserGroupResponses = {
 
 export type PermitUserGroupResponse =
   PermitUserGroupResponses[keyof PermitUserGroupResponses]
+
+export type ShowUsageData = {
+  body?: never
+  path?: never
+  query?: never
+  url: '/usage'
+}
+
+export type ShowUsageErrors = {
+  /**
+   * User is not authorized
+   */
+  403: Notification
+  /**
+   * Some internal server error
+   */
+  500: Notification
+}
+
+export type ShowUsageError = ShowUsageErrors[keyof ShowUsageErrors]
+
+export type ShowUsageResponses = {
+  /**
+   * The storage usage of users and groups
+   */
+  200: {
+    users: Array<Usage>
+    groups: Array<Usage>
+  }
+}
+
+export type ShowUsageResponse = ShowUsageResponses[keyof ShowUsageResponses]
@@ -0,0 +1,325 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/gopad/gopad-api/pkg/avatar"
+	"github.com/gopad/gopad-api/pkg/identicon"
+	"github.com/gopad/gopad-api/pkg/middleware/current"
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/gopad/gopad-api/pkg/store"
+	"github.com/rs/zerolog/log"
+)
+
+// CreateProfileAvatar implements the v1.ServerInterface.
+func (a *API) CreateProfileAvatar(w http.ResponseWriter, r *http.Request) {
+	record := current.GetUser(
+		r.Context(),
+	)
+
+	if !a.saveAvatar(w, r, "user", record.ID, "CreateProfileAvatar", func(content *bytes.Buffer) error {
+		return a.storage.Users.SaveAvatar(r.Context(), record, content)
+	}) {
+		return
+	}
+
+	render.JSON(w, r, ProfileResponse(
+		a.convertProfile(
+			record,
+		),
+	))
+}
+
+// DeleteProfileAvatar implements the v1.ServerInterface.
+func (a *API) DeleteProfileAvatar(w http.ResponseWriter, r *http.Request) {
+	record := current.GetUser(
+		r.Context(),
+	)
+
+	if !a.deleteAvatar(w, r, "user", record.ID, "DeleteProfileAvatar", func() error {
+		return a.storage.Users.DeleteAvatar(r.Context(), record)
+	}) {
+		return
+	}
+
+	render.JSON(w, r, ProfileResponse(
+		a.convertProfile(
+			record,
+		),
+	))
+}
+
+// CreateUserAvatar implements the v1.ServerInterface.
+func (a *API) CreateUserAvatar(w http.ResponseWriter, r *http.Request, _ UserID) {
+	record := a.UserFromContext(
+		r.Context(),
+	)
+
+	if !a.saveAvatar(w, r, "user", record.ID, "CreateUserAvatar", func(content *bytes.Buffer) error {
+		return a.storage.Users.SaveAvatar(r.Context(), record, content)
+	}) {
+		return
+	}
+
+	render.JSON(w, r, UserResponse(
+		a.convertUser(
+			record,
+		),
+	))
+}
+
+// DeleteUserAvatar implements the v1.ServerInterface.
+func (a *API) DeleteUserAvatar(w http.ResponseWriter, r *http.Request, _ UserID) {
+	record := a.UserFromContext(
+		r.Context(),
+	)
+
+	if !a.deleteAvatar(w, r, "user", record.ID, "DeleteUserAvatar", func() error {
+		return a.storage.Users.DeleteAvatar(r.Context(), record)
+	}) {
+		return
+	}
+
+	render.JSON(w, r, UserResponse(
+		a.convertUser(
+			record,
+		),
+	))
+}
+
+// CreateGroupAvatar implements the v1.ServerInterface.
+func (a *API) CreateGroupAvatar(w http.ResponseWriter, r *http.Request, _ GroupID) {
+	record := a.GroupFromContext(
+		r.Context(),
+	)
+
+	if !a.saveAvatar(w, r, "group", record.ID, "CreateGroupAvatar", func(content *bytes.Buffer) error {
+		return a.storage.Groups.SaveAvatar(r.Context(), record, content)
+	}) {
+		return
+	}
+
+	render.JSON(w, r, GroupResponse(
+		a.convertGroup(
+			record,
+		),
+	))
+}
+
+// DeleteGroupAvatar implements the v1.ServerInterface.
+func (a *API) DeleteGroupAvatar(w http.ResponseWriter, r *http.Request, _ GroupID) {
+	record := a.GroupFromContext(
+		r.Context(),
+	)
+
+	if !a.deleteAvatar(w, r, "group", record.ID, "DeleteGroupAvatar", func() error {
+		return a.storage.Groups.DeleteAvatar(r.Context(), record)
+	}) {
+		return
+	}
+
+	render.JSON(w, r, GroupResponse(
+		a.convertGroup(
+			record,
+		),
+	))
+}
+
+// Identicon renders a generated fallback avatar for the requested name.
+func (a *API) Identicon(w http.ResponseWriter, r *http.Request) {
+	result, err := identicon.New(
+		"gopad",
+		chi.URLParam(r, "identicon"),
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("action", "Identicon").
+			Msg("Failed to generate identicon")
+
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Failed to generate identicon"),
+			Status:  ToPtr(http.StatusInternalServerError),
+		})
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = result.WriteTo(w)
+}
+
+func (a *API) saveAvatar(w http.ResponseWriter, r *http.Request, owner, id, action string, save func(*bytes.Buffer) error) bool {
+	content, err := io.ReadAll(
+		io.LimitReader(r.Body, a.config.Avatar.Limit+1),
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("action", action).
+			Msg("Failed to read request body")
+
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Failed to decode request"),
+			Status:  ToPtr(http.StatusBadRequest),
+		})
+
+		return false
+	}
+
+	if int64(len(content)) > a.config.Avatar.Limit {
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Avatar exceeds the upload limit"),
+			Status:  ToPtr(http.StatusRequestEntityTooLarge),
+		})
+
+		return false
+	}
+
+	resized, err := avatar.Resize(
+		bytes.NewReader(content),
+		a.config.Avatar.Size,
+	)
+
+	if errors.Is(err, avatar.ErrUnsupportedFormat) {
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Unsupported avatar format"),
+			Status:  ToPtr(http.StatusBadRequest),
+		})
+
+		return false
+	}
+
+	if errors.Is(err, avatar.ErrTooLarge) {
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Avatar dimensions are too large"),
+			Status:  ToPtr(http.StatusRequestEntityTooLarge),
+		})
+
+		return false
+	}
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str(owner, id).
+			Str("action", action).
+			Msg("Failed to process avatar")
+
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Failed to process avatar"),
+			Status:  ToPtr(http.StatusInternalServerError),
+		})
+
+		return false
+	}
+
+	if err := save(resized); err != nil {
+		if errors.Is(err, store.ErrQuotaExceeded) {
+			a.RenderNotify(w, r, Notification{
+				Message: ToPtr("Storage quota exceeded"),
+				Status:  ToPtr(http.StatusRequestEntityTooLarge),
+			})
+
+			return false
+		}
+
+		log.Error().
+			Err(err).
+			Str(owner, id).
+			Str("action", action).
+			Msg("Failed to store avatar")
+
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Failed to store avatar"),
+			Status:  ToPtr(http.StatusInternalServerError),
+		})
+
+		return false
+	}
+
+	return true
+}
+
+func (a *API) deleteAvatar(w http.ResponseWriter, r *http.Request, owner, id, action string, remove func() error) bool {
+	if err := remove(); err != nil {
+		log.Error().
+			Err(err).
+			Str(owner, id).
+			Str("action", action).
+			Msg("Failed to delete avatar")
+
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Failed to delete avatar"),
+			Status:  ToPtr(http.StatusInternalServerError),
+		})
+
+		return false
+	}
+
+	return true
+}
+
+// avatarFor resolves the avatar URL of an user, uploaded avatars take
+// precedence over gravatar and generated identicons.
+func (a *API) avatarFor(record *model.User) string {
+	host := strings.TrimSuffix(a.config.Server.Host, "/")
+
+	if record.Avatar != "" {
+		return host + path.Join(
+			a.config.Server.Root,
+			"api",
+			"v1",
+			"storage",
+			record.Avatar,
+		)
+	}
+
+	if a.config.Avatar.Gravatar {
+		return gravatarFor(record.Email)
+	}
+
+	return host + path.Join(
+		a.config.Server.Root,
+		"api",
+		"v1",
+		"identicons",
+		record.ID,
+	)
+}
+
+// groupAvatarFor resolves the avatar URL of a group, uploaded avatars take
+// precedence over generated identicons.
+func (a *API) groupAvatarFor(record *model.Group) string {
+	host := strings.TrimSuffix(a.config.Server.Host, "/")
+
+	if record.Avatar != "" {
+		return host + path.Join(
+			a.config.Server.Root,
+			"api",
+			"v1",
+			"storage",
+			record.Avatar,
+		)
+	}
+
+	return host + path.Join(
+		a.config.Server.Root,
+		"api",
+		"v1",
+		"identicons",
+		record.ID,
+	)
+}
@@ -24,8 +24,13 @@ func (a *API) GroupToContext(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		id := chi.URLParam(r, "group_id")
+		show := a.storage.Groups.ShowPrimary
 
-		record, err := a.storage.Groups.Show(
+		if readOnly(r) {
+			show = a.storage.Groups.Show
+		}
+
+		record, err := show(
 			ctx,
 			id,
 		)
@@ -80,8 +85,13 @@ func (a *API) UserToContext(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 		id := chi.URLParam(r, "user_id")
+		show := a.storage.Users.ShowPrimary
+
+		if readOnly(r) {
+			show = a.storage.Users.Show
+		}
 
-		record, err := a.storage.Users.Show(
+		record, err := show(
 			ctx,
 			id,
 		)
@@ -130,3 +140,9 @@ func (a *API) UserFromContext(ctx context.Context) *model.User {
 
 	return record
 }
+
+// readOnly defines if the requested record could be loaded from a replica,
+// records for any other request may get written back to the primary.
+func readOnly(r *http.Request) bool {
+	return r.Method == http.MethodGet || r.Method == http.MethodHead
+}
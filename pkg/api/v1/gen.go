@@ -95,6 +95,24 @@ func (e ListGroupUsersParamsOrder) Valid() bool {
 	}
 }
 
+// Defines values for ListProfileNotificationsParamsOrder.
+const (
+	ListProfileNotificationsParamsOrderAsc  ListProfileNotificationsParamsOrder = "asc"
+	ListProfileNotificationsParamsOrderDesc ListProfileNotificationsParamsOrder = "desc"
+)
+
+// Valid indicates whether the value is a known member of the ListProfileNotificationsParamsOrder enum.
+func (e ListProfileNotificationsParamsOrder) Valid() bool {
+	switch e {
+	case ListProfileNotificationsParamsOrderAsc:
+		return true
+	case ListProfileNotificationsParamsOrderDesc:
+		return true
+	default:
+		return false
+	}
+}
+
 // Defines values for ListUsersParamsOrder.
 const (
 	ListUsersParamsOrderAsc  ListUsersParamsOrder = "asc"
@@ -148,6 +166,7 @@ type Group struct {
 	CreatedAt *time.Time `json:"created_at,omitempty"`
 	ID        *string    `json:"id,omitempty"`
 	Name      *string    `json:"name,omitempty"`
+	Profile   *string    `json:"profile,omitempty"`
 	Slug      *string    `json:"slug,omitempty"`
 	UpdatedAt *time.Time `json:"updated_at,omitempty"`
 }
@@ -165,6 +184,7 @@ type Profile struct {
 	Admin     *bool        `json:"admin,omitempty"`
 	Auths     *[]UserAuth  `json:"auths,omitempty"`
 	CreatedAt *time.Time   `json:"created_at,omitempty"`
+	Digest    *bool        `json:"digest,omitempty"`
 	Email     *string      `json:"email,omitempty"`
 	Fullname  *string      `json:"fullname,omitempty"`
 	Groups    *[]UserGroup `json:"groups,omitempty"`
@@ -175,6 +195,17 @@ type Profile struct {
 	Username  *string      `json:"username,omitempty"`
 }
 
+// ProfileNotification Model to represent profile notification
+type ProfileNotification struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	ID        *string    `json:"id,omitempty"`
+	Kind      *string    `json:"kind,omitempty"`
+	Message   *string    `json:"message,omitempty"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	Reference *string    `json:"reference,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
 // Provider Model to represent auth provider
 type Provider struct {
 	Display *string `json:"display,omitempty"`
@@ -183,6 +214,16 @@ type Provider struct {
 	Name    *string `json:"name,omitempty"`
 }
 
+// Usage Storage usage of an user or group
+type Usage struct {
+	Bytes      *int64  `json:"bytes,omitempty"`
+	ID         *string `json:"id,omitempty"`
+	MaxBytes   *int64  `json:"max_bytes,omitempty"`
+	MaxObjects *int64  `json:"max_objects,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Objects    *int64  `json:"objects,omitempty"`
+}
+
 // User Model to represent user
 type User struct {
 	Active    *bool       `json:"active,omitempty"`
@@ -242,6 +283,18 @@ type AuthStateParam = string
 // GroupID defines model for GroupParam.
 type GroupID = string
 
+// NotificationID defines model for NotificationParam.
+type NotificationID = string
+
+// NotificationUnreadParam defines model for NotificationUnreadParam.
+type NotificationUnreadParam = bool
+
+// PagingCountParam defines model for PagingCountParam.
+type PagingCountParam = bool
+
+// PagingCursorParam defines model for PagingCursorParam.
+type PagingCursorParam = string
+
 // PagingLimitParam defines model for PagingLimitParam.
 type PagingLimitParam = int
 
@@ -280,8 +333,10 @@ type GroupUsersResponse struct {
 	// Group Model to represent group
 	Group  *Group      `json:"group,omitempty"`
 	Limit  int64       `json:"limit"`
+	Next   *string     `json:"next,omitempty"`
 	Offset int64       `json:"offset"`
-	Total  int64       `json:"total"`
+	Prev   *string     `json:"prev,omitempty"`
+	Total  *int64      `json:"total,omitempty"`
 	Users  []UserGroup `json:"users"`
 }
 
@@ -289,8 +344,10 @@ type GroupUsersResponse struct {
 type GroupsResponse struct {
 	Groups []Group `json:"groups"`
 	Limit  int64   `json:"limit"`
+	Next   *string `json:"next,omitempty"`
 	Offset int64   `json:"offset"`
-	Total  int64   `json:"total"`
+	Prev   *string `json:"prev,omitempty"`
+	Total  *int64  `json:"total,omitempty"`
 }
 
 // InternalServerError Generic response for errors and validations
@@ -311,6 +368,24 @@ type NotAuthorizedError = Notification
 // NotFoundError Generic response for errors and validations
 type NotFoundError = Notification
 
+// ProfileNotificationResponse Model to represent profile notification
+type ProfileNotificationResponse = ProfileNotification
+
+// ProfileNotificationsResponse defines model for ProfileNotificationsResponse.
+type ProfileNotificationsResponse struct {
+	Limit         int64                 `json:"limit"`
+	Next          *string               `json:"next,omitempty"`
+	Notifications []ProfileNotification `json:"notifications"`
+	Offset        int64                 `json:"offset"`
+	Prev          *string               `json:"prev,omitempty"`
+	Total         *int64                `json:"total,omitempty"`
+}
+
+// ProfileNotificationsUnreadResponse defines model for ProfileNotificationsUnreadResponse.
+type ProfileNotificationsUnreadResponse struct {
+	Unread int64 `json:"unread"`
+}
+
 // ProfileResponse Model to represent profile
 type ProfileResponse = Profile
 
@@ -329,12 +404,23 @@ type SuccessMessage = Notification
 // TokenResponse defines model for TokenResponse.
 type TokenResponse = AuthToken
 
+// TooLargeError Generic response for errors and validations
+type TooLargeError = Notification
+
+// UsageResponse defines model for UsageResponse.
+type UsageResponse struct {
+	Groups []Usage `json:"groups"`
+	Users  []Usage `json:"users"`
+}
+
 // UserGroupsResponse defines model for UserGroupsResponse.
 type UserGroupsResponse struct {
 	Groups []UserGroup `json:"groups"`
 	Limit  int64       `json:"limit"`
+	Next   *string     `json:"next,omitempty"`
 	Offset int64       `json:"offset"`
-	Total  int64       `json:"total"`
+	Prev   *string     `json:"prev,omitempty"`
+	Total  *int64      `json:"total,omitempty"`
 
 	// User Model to represent user
 	User *User `json:"user,omitempty"`
@@ -345,10 +431,12 @@ type UserResponse = User
 
 // UsersResponse defines model for UsersResponse.
 type UsersResponse struct {
-	Limit  int64  `json:"limit"`
-	Offset int64  `json:"offset"`
-	Total  int64  `json:"total"`
-	Users  []User `json:"users"`
+	Limit  int64   `json:"limit"`
+	Next   *string `json:"next,omitempty"`
+	Offset int64   `json:"offset"`
+	Prev   *string `json:"prev,omitempty"`
+	Total  *int64  `json:"total,omitempty"`
+	Users  []User  `json:"users"`
 }
 
 // ValidationError Generic response for errors and validations
@@ -403,6 +491,7 @@ type UpdateGroupBody struct {
 
 // UpdateProfileBody defines model for UpdateProfileBody.
 type UpdateProfileBody struct {
+	Digest   *bool   `json:"digest,omitempty"`
 	Email    *string `json:"email,omitempty"`
 	Fullname *string `json:"fullname,omitempty"`
 	Password *string `json:"password,omitempty"`
@@ -466,6 +555,12 @@ type ListGroupsParams struct {
 
 	// Offset Paging offset
 	Offset *PagingOffsetParam `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Paging cursor, takes precedence over the offset
+	Cursor *PagingCursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Count Count the total of all matching records
+	Count *PagingCountParam `form:"count,omitempty" json:"count,omitempty"`
 }
 
 // ListGroupsParamsOrder defines parameters for ListGroups.
@@ -504,6 +599,12 @@ type ListGroupUsersParams struct {
 
 	// Offset Paging offset
 	Offset *PagingOffsetParam `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Paging cursor, takes precedence over the offset
+	Cursor *PagingCursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Count Count the total of all matching records
+	Count *PagingCountParam `form:"count,omitempty" json:"count,omitempty"`
 }
 
 // ListGroupUsersParamsOrder defines parameters for ListGroupUsers.
@@ -521,8 +622,36 @@ type PermitGroupUserJSONBody struct {
 	User string `json:"user"`
 }
 
+// ListProfileNotificationsParams defines parameters for ListProfileNotifications.
+type ListProfileNotificationsParams struct {
+	// Unread Only list unread notifications
+	Unread *NotificationUnreadParam `form:"unread,omitempty" json:"unread,omitempty"`
+
+	// Sort Sorting column
+	Sort *SortColumnParam `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Order Sorting order
+	Order *ListProfileNotificationsParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// Limit Paging limit
+	Limit *PagingLimitParam `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Paging offset
+	Offset *PagingOffsetParam `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Paging cursor, takes precedence over the offset
+	Cursor *PagingCursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Count Count the total of all matching records
+	Count *PagingCountParam `form:"count,omitempty" json:"count,omitempty"`
+}
+
+// ListProfileNotificationsParamsOrder defines parameters for ListProfileNotifications.
+type ListProfileNotificationsParamsOrder string
+
 // UpdateProfileJSONBody defines parameters for UpdateProfile.
 type UpdateProfileJSONBody struct {
+	Digest   *bool   `json:"digest,omitempty"`
 	Email    *string `json:"email,omitempty"`
 	Fullname *string `json:"fullname,omitempty"`
 	Password *string `json:"password,omitempty"`
@@ -545,6 +674,12 @@ type ListUsersParams struct {
 
 	// Offset Paging offset
 	Offset *PagingOffsetParam `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Paging cursor, takes precedence over the offset
+	Cursor *PagingCursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Count Count the total of all matching records
+	Count *PagingCountParam `form:"count,omitempty" json:"count,omitempty"`
 }
 
 // ListUsersParamsOrder defines parameters for ListUsers.
@@ -591,6 +726,12 @@ type ListUserGroupsParams struct {
 
 	// Offset Paging offset
 	Offset *PagingOffsetParam `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Paging cursor, takes precedence over the offset
+	Cursor *PagingCursorParam `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Count Count the total of all matching records
+	Count *PagingCountParam `form:"count,omitempty" json:"count,omitempty"`
 }
 
 // ListUserGroupsParamsOrder defines parameters for ListUserGroups.
@@ -685,6 +826,12 @@ type ServerInterface interface {
 	// UpdateGroup Update a specific group
 	// (PUT /groups/{group_id})
 	UpdateGroup(w http.ResponseWriter, r *http.Request, groupID GroupID)
+	// DeleteGroupAvatar Delete the avatar of a specific group
+	// (DELETE /groups/{group_id}/avatar)
+	DeleteGroupAvatar(w http.ResponseWriter, r *http.Request, groupID GroupID)
+	// CreateGroupAvatar Upload an avatar for a specific group
+	// (POST /groups/{group_id}/avatar)
+	CreateGroupAvatar(w http.ResponseWriter, r *http.Request, groupID GroupID)
 	// DeleteGroupFromUser Unlink a user from group
 	// (DELETE /groups/{group_id}/users)
 	DeleteGroupFromUser(w http.ResponseWriter, r *http.Request, groupID GroupID)
@@ -697,6 +844,24 @@ type ServerInterface interface {
 	// PermitGroupUser Update user perms for group
 	// (PUT /groups/{group_id}/users)
 	PermitGroupUser(w http.ResponseWriter, r *http.Request, groupID GroupID)
+	// DeleteProfileAvatar Delete the avatar of your own profile
+	// (DELETE /profile/avatar)
+	DeleteProfileAvatar(w http.ResponseWriter, r *http.Request)
+	// CreateProfileAvatar Upload an avatar for your own profile
+	// (POST /profile/avatar)
+	CreateProfileAvatar(w http.ResponseWriter, r *http.Request)
+	// ListProfileNotifications Fetch the notifications of your own profile
+	// (GET /profile/notifications)
+	ListProfileNotifications(w http.ResponseWriter, r *http.Request, params ListProfileNotificationsParams)
+	// ReadAllProfileNotifications Mark all notifications of your own profile as read
+	// (POST /profile/notifications/read)
+	ReadAllProfileNotifications(w http.ResponseWriter, r *http.Request)
+	// UnreadProfileNotifications Fetch the unread notification count of your own profile
+	// (GET /profile/notifications/unread)
+	UnreadProfileNotifications(w http.ResponseWriter, r *http.Request)
+	// ReadProfileNotification Mark a notification of your own profile as read
+	// (POST /profile/notifications/{notification_id}/read)
+	ReadProfileNotification(w http.ResponseWriter, r *http.Request, notificationID NotificationID)
 	// ShowProfile Fetch profile details of the personal account
 	// (GET /profile/self)
 	ShowProfile(w http.ResponseWriter, r *http.Request)
@@ -706,6 +871,9 @@ type ServerInterface interface {
 	// TokenProfile Retrieve an unlimited auth token
 	// (GET /profile/token)
 	TokenProfile(w http.ResponseWriter, r *http.Request)
+	// ShowUsage Fetch the storage usage of users and groups
+	// (GET /usage)
+	ShowUsage(w http.ResponseWriter, r *http.Request)
 	// ListUsers Fetch all available users
 	// (GET /users)
 	ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams)
@@ -721,6 +889,12 @@ type ServerInterface interface {
 	// UpdateUser Update a specific user
 	// (PUT /users/{user_id})
 	UpdateUser(w http.ResponseWriter, r *http.Request, userID UserID)
+	// DeleteUserAvatar Delete the avatar of a specific user
+	// (DELETE /users/{user_id}/avatar)
+	DeleteUserAvatar(w http.ResponseWriter, r *http.Request, userID UserID)
+	// CreateUserAvatar Upload an avatar for a specific user
+	// (POST /users/{user_id}/avatar)
+	CreateUserAvatar(w http.ResponseWriter, r *http.Request, userID UserID)
 	// DeleteUserFromGroup Unlink a group from user
 	// (DELETE /users/{user_id}/groups)
 	DeleteUserFromGroup(w http.ResponseWriter, r *http.Request, userID UserID)
@@ -811,6 +985,18 @@ func (_ Unimplemented) UpdateGroup(w http.ResponseWriter, r *http.Request, group
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// DeleteGroupAvatar Delete the avatar of a specific group
+// (DELETE /groups/{group_id}/avatar)
+func (_ Unimplemented) DeleteGroupAvatar(w http.ResponseWriter, r *http.Request, groupID GroupID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// CreateGroupAvatar Upload an avatar for a specific group
+// (POST /groups/{group_id}/avatar)
+func (_ Unimplemented) CreateGroupAvatar(w http.ResponseWriter, r *http.Request, groupID GroupID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // DeleteGroupFromUser Unlink a user from group
 // (DELETE /groups/{group_id}/users)
 func (_ Unimplemented) DeleteGroupFromUser(w http.ResponseWriter, r *http.Request, groupID GroupID) {
@@ -835,6 +1021,42 @@ func (_ Unimplemented) PermitGroupUser(w http.ResponseWriter, r *http.Request, g
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// DeleteProfileAvatar Delete the avatar of your own profile
+// (DELETE /profile/avatar)
+func (_ Unimplemented) DeleteProfileAvatar(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// CreateProfileAvatar Upload an avatar for your own profile
+// (POST /profile/avatar)
+func (_ Unimplemented) CreateProfileAvatar(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ListProfileNotifications Fetch the notifications of your own profile
+// (GET /profile/notifications)
+func (_ Unimplemented) ListProfileNotifications(w http.ResponseWriter, r *http.Request, params ListProfileNotificationsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ReadAllProfileNotifications Mark all notifications of your own profile as read
+// (POST /profile/notifications/read)
+func (_ Unimplemented) ReadAllProfileNotifications(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// UnreadProfileNotifications Fetch the unread notification count of your own profile
+// (GET /profile/notifications/unread)
+func (_ Unimplemented) UnreadProfileNotifications(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ReadProfileNotification Mark a notification of your own profile as read
+// (POST /profile/notifications/{notification_id}/read)
+func (_ Unimplemented) ReadProfileNotification(w http.ResponseWriter, r *http.Request, notificationID NotificationID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ShowProfile Fetch profile details of the personal account
 // (GET /profile/self)
 func (_ Unimplemented) ShowProfile(w http.ResponseWriter, r *http.Request) {
@@ -853,6 +1075,12 @@ func (_ Unimplemented) TokenProfile(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// ShowUsage Fetch the storage usage of users and groups
+// (GET /usage)
+func (_ Unimplemented) ShowUsage(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ListUsers Fetch all available users
 // (GET /users)
 func (_ Unimplemented) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
@@ -883,6 +1111,18 @@ func (_ Unimplemented) UpdateUser(w http.ResponseWriter, r *http.Request, userID
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// DeleteUserAvatar Delete the avatar of a specific user
+// (DELETE /users/{user_id}/avatar)
+func (_ Unimplemented) DeleteUserAvatar(w http.ResponseWriter, r *http.Request, userID UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// CreateUserAvatar Upload an avatar for a specific user
+// (POST /users/{user_id}/avatar)
+func (_ Unimplemented) CreateUserAvatar(w http.ResponseWriter, r *http.Request, userID UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // DeleteUserFromGroup Unlink a group from user
 // (DELETE /users/{user_id}/groups)
 func (_ Unimplemented) DeleteUserFromGroup(w http.ResponseWriter, r *http.Request, userID UserID) {
@@ -1141,6 +1381,32 @@ func (siw *ServerInterfaceWrapper) ListGroups(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", r.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "cursor"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "count" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "count", r.URL.Query(), &params.Count, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "count"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "count", Err: err})
+		}
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.ListGroups(w, r, params)
 	}))
@@ -1244,6 +1510,58 @@ func (siw *ServerInterfaceWrapper) UpdateGroup(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
+// DeleteGroupAvatar operation middleware
+func (siw *ServerInterfaceWrapper) DeleteGroupAvatar(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "group_id" -------------
+	var groupID GroupID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group_id", chi.URLParam(r, "group_id"), &groupID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "", ValueIsUnescaped: r.URL.RawPath == ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "group_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteGroupAvatar(w, r, groupID)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateGroupAvatar operation middleware
+func (siw *ServerInterfaceWrapper) CreateGroupAvatar(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "group_id" -------------
+	var groupID GroupID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "group_id", chi.URLParam(r, "group_id"), &groupID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "", ValueIsUnescaped: r.URL.RawPath == ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "group_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateGroupAvatar(w, r, groupID)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // DeleteGroupFromUser operation middleware
 func (siw *ServerInterfaceWrapper) DeleteGroupFromUser(w http.ResponseWriter, r *http.Request) {
 
@@ -1353,6 +1671,32 @@ func (siw *ServerInterfaceWrapper) ListGroupUsers(w http.ResponseWriter, r *http
 		return
 	}
 
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", r.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "cursor"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "count" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "count", r.URL.Query(), &params.Count, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "count"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "count", Err: err})
+		}
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.ListGroupUsers(w, r, groupID, params)
 	}))
@@ -1416,6 +1760,199 @@ func (siw *ServerInterfaceWrapper) PermitGroupUser(w http.ResponseWriter, r *htt
 	handler.ServeHTTP(w, r)
 }
 
+// DeleteProfileAvatar operation middleware
+func (siw *ServerInterfaceWrapper) DeleteProfileAvatar(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteProfileAvatar(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateProfileAvatar operation middleware
+func (siw *ServerInterfaceWrapper) CreateProfileAvatar(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProfileAvatar(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListProfileNotifications operation middleware
+func (siw *ServerInterfaceWrapper) ListProfileNotifications(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListProfileNotificationsParams
+
+	// ------------- Optional query parameter "unread" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "unread", r.URL.Query(), &params.Unread, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "unread"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "unread", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "sort", r.URL.Query(), &params.Sort, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "sort"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "order", r.URL.Query(), &params.Order, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "order"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "order", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "limit"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "offset", r.URL.Query(), &params.Offset, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "offset"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "offset", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", r.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "cursor"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "count" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "count", r.URL.Query(), &params.Count, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "count"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "count", Err: err})
+		}
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListProfileNotifications(w, r, params)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReadAllProfileNotifications operation middleware
+func (siw *ServerInterfaceWrapper) ReadAllProfileNotifications(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadAllProfileNotifications(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// UnreadProfileNotifications operation middleware
+func (siw *ServerInterfaceWrapper) UnreadProfileNotifications(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UnreadProfileNotifications(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ReadProfileNotification operation middleware
+func (siw *ServerInterfaceWrapper) ReadProfileNotification(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "notification_id" -------------
+	var notificationID NotificationID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "notification_id", chi.URLParam(r, "notification_id"), &notificationID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "", ValueIsUnescaped: r.URL.RawPath == ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "notification_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReadProfileNotification(w, r, notificationID)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // ShowProfile operation middleware
 func (siw *ServerInterfaceWrapper) ShowProfile(w http.ResponseWriter, r *http.Request) {
 
@@ -1458,6 +1995,20 @@ func (siw *ServerInterfaceWrapper) TokenProfile(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r)
 }
 
+// ShowUsage operation middleware
+func (siw *ServerInterfaceWrapper) ShowUsage(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ShowUsage(w, r)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // ListUsers operation middleware
 func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Request) {
 
@@ -1532,6 +2083,32 @@ func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", r.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "cursor"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "count" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "count", r.URL.Query(), &params.Count, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "count"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "count", Err: err})
+		}
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.ListUsers(w, r, params)
 	}))
@@ -1635,6 +2212,58 @@ func (siw *ServerInterfaceWrapper) UpdateUser(w http.ResponseWriter, r *http.Req
 	handler.ServeHTTP(w, r)
 }
 
+// DeleteUserAvatar operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUserAvatar(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "user_id" -------------
+	var userID UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "", ValueIsUnescaped: r.URL.RawPath == ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteUserAvatar(w, r, userID)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateUserAvatar operation middleware
+func (siw *ServerInterfaceWrapper) CreateUserAvatar(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+	_ = err
+
+	// ------------- Path parameter "user_id" -------------
+	var userID UserID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "user_id", chi.URLParam(r, "user_id"), &userID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: "", ValueIsUnescaped: r.URL.RawPath == ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateUserAvatar(w, r, userID)
+	}))
+
+	for i := len(siw.HandlerMiddlewares) - 1; i >= 0; i-- {
+		handler = siw.HandlerMiddlewares[i](handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // DeleteUserFromGroup operation middleware
 func (siw *ServerInterfaceWrapper) DeleteUserFromGroup(w http.ResponseWriter, r *http.Request) {
 
@@ -1744,6 +2373,32 @@ func (siw *ServerInterfaceWrapper) ListUserGroups(w http.ResponseWriter, r *http
 		return
 	}
 
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", r.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "cursor"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		}
+		return
+	}
+
+	// ------------- Optional query parameter "count" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "count", r.URL.Query(), &params.Count, runtime.BindQueryParameterOptions{Type: "boolean", Format: ""})
+	if err != nil {
+		var requiredError *runtime.RequiredParameterError
+		if errors.As(err, &requiredError) {
+			siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "count"})
+		} else {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "count", Err: err})
+		}
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.ListUserGroups(w, r, userID, params)
 	}))
@@ -1950,6 +2605,24 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/profile/self", wrapper.UpdateProfile)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/profile/avatar", wrapper.DeleteProfileAvatar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/profile/avatar", wrapper.CreateProfileAvatar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/profile/notifications", wrapper.ListProfileNotifications)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/profile/notifications/unread", wrapper.UnreadProfileNotifications)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/profile/notifications/read", wrapper.ReadAllProfileNotifications)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/profile/notifications/{notification_id}/read", wrapper.ReadProfileNotification)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/groups", wrapper.ListGroups)
 	})
@@ -1965,6 +2638,12 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/groups/{group_id}", wrapper.UpdateGroup)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/groups/{group_id}/avatar", wrapper.DeleteGroupAvatar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/groups/{group_id}/avatar", wrapper.CreateGroupAvatar)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/groups/{group_id}/users", wrapper.DeleteGroupFromUser)
 	})
@@ -1992,6 +2671,12 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/users/{user_id}", wrapper.UpdateUser)
 	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/users/{user_id}/avatar", wrapper.DeleteUserAvatar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/users/{user_id}/avatar", wrapper.CreateUserAvatar)
+	})
 	r.Group(func(r chi.Router) {
 		r.Delete(options.BaseURL+"/users/{user_id}/groups", wrapper.DeleteUserFromGroup)
 	})
@@ -2004,6 +2689,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/users/{user_id}/groups", wrapper.PermitUserGroup)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/usage", wrapper.ShowUsage)
+	})
 
 	return r
 }
@@ -2013,61 +2701,73 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // const string: with thousands of chunks the chained `+` fold is several
 // times slower for the Go compiler than parsing a slice literal.
 var swaggerSpec = []string{
-	"7Dxbb9s41n9F4Pc9KnHS6S4Wftq003aDnZlmk3awQBEMGOnIZiuJGpKK6wn03xe8SKJuNi0rcTLjN1vi",
-	"5dx5btQDCmiS0RRSwdH8AWWY4QQEMPXvIhfLtzSEK/lUPgiBB4xkgtAUzdVrL6AhIB8R+eD3HNga+SjF",
-	"CaA5Mq94sIQEy+lincnnXDCSLlBR+GqJK0bvSQhsaJfUIyGkgkQEmBdR5okleFjunZmZ5f4ZFst6e+st",
-	"g99zwiBEc8Fy2ACSj76fwHecZLF8uiBimd8hA+eNwGIjKbgcMECL8t0mYnxgNM+GNvAW8q1NCso8HueL",
-	"fuTV6N9IuAfycoWTcySfLuiJWVfBePmjHHyFFyRd/EQSIgaA1iO8WA4ZoEv5rgYqhAjnsUDz87MzvwSR",
-	"pAIWwFownp+dVXB8jCIOWwChaswAJNXLHlC2ASLBuAHMguV/5KIDUOgRXrlvr5ioIVvk5IYy8ZbGeZIO",
-	"bUSZkPgGatDQVpQJh40+smHVLPehzFLCNlnNux6qIswD5CNI8wTNv5h/cgd062+WTTWo8NFnPmw2vJwD",
-	"c9YXOXgvdfkaUmjpioROqkqhFwUu3tCQgDKtbxlgAUqb3tBwLR8FNBWQCvkTZ1lMAixRmX3lEp8HC46M",
-	"0QyYMCvpzdrQSUjSPI7xnYROY/L9hCZEQJKJtX5U+EhRZOTsouISvfsKgUCFfNRkw6clGMsVYoE9Qb1A",
-	"Yd4hdOEbmkiq7UkSHAhyDw1Z0zgYcO8ojQGnzkTCYULSxnIRjvn49SDBJB7PsyiP4/24nmHOV5SFcoWI",
-	"sgQLpRDmoT9yValD+8DlKk9KsbeLk1IuKU0/Mrqvkskt+21kve0XPep2J61o4JKnMUm/bcTlCliyJy4Z",
-	"sKTXnO2ApK9X2QNXOV+d/B1cf6ILkkqHal88dxLyjfLbRwM10lIlV1oEDNSZhGMu6SAdWfk3GJDiawgJ",
-	"g0BMQBBBv0G6HTk9zBUdZuDz1DQXjD5n4V/06MsV5sM0uWI0IjHsSZXj6bIXyzLNBGemTeqv7OugHB2S",
-	"gzskG+SFA1NmbwKHRBmW7bZcD7vdBY+WyRp0SSpsJnBJhrDxh5yVXjR3dEp6sB1yStR2PKMp1/BeBHKd",
-	"95jEEL5jjLKdcP9/BhGao/+b1bm3mX7LZ79QGa3qqX2A6z0lrPAdglyAhxUsKjHGgNOcBaAyVjEDHK4v",
-	"hMDB8qmhvDaAeIR7WAPiYQOJBO4NDt/WbtDTwvY5lQ4KZeQPCL0VEUtvxWi6sP0yA+K1DtoPxeAMMy79",
-	"KwUEKoOAayOHkwGkVh3SkRAEJjFX0oW1siA7HOGjwBlQfgcofZMwtI8Jkoq/v0Z9GTqT0nMbLKjAseNY",
-	"aToU7PK84Ntgrywlqg8UzBhe97jeEga/yopWOUm9oYtlu/ACGsegjQKNrNCLV5ybjGvuNBjA/1nw05UJ",
-	"BuXRXFAMuEyFdEriG2D3wJ7WttzQBDxiAPC4gsADBYKC7B7HJPwk47hD2bwFpMCwAI8yT0Ejf+uAtMwM",
-	"TG4AZWT9SW/RBe2DASg08a06MOB7RpiKENRR+wsVhz9mUyoaR6wEqjrnnviEVYl3A1IFgwHqPc3TQ5FJ",
-	"AhTJ/VX5Ssd5k4uTWXcw/ZMzBqmow0x9whqIVMFyCvNcFj/dLXS5e5+R3tuW1uCMsZ6NYq8i1TVEDPjy",
-	"aW2B2XSjLbjJgwA4/xk4xwt4MhG/ijFJPa439xKze+EjhcxhTWYK6piRdDJBXeUQHcAR2eCMPSPn0gWL",
-	"J3Fc6iCZl5ybXJoMMtsDkFSBU8IxhfC80GjiEIFEFUL8qv0yQg/mJVaeoRUc/wqMROtHsXR66T6QfgaB",
-	"Ve6IRnVXknFXi7J1oGql+lTWX1r5enWC8N9wU7YkgieCJDqViMOPabxu1bF3TIdW9Z/Ny7nWg3RXlCFO",
-	"Bytdlw0fHys7ybsbYtXMPtw+lFmJFsdpCLGUQQYZAy49uSr5OC3+hY9I2J+5rltMdCfW4epgPtLp7n3w",
-	"lJJJRFx1l6EebjRsQ4cpyuMggVfmaNVpoeJa7uE0LO0FoSnv8EkPc7a+tfHr8x+S2u3rUrOH8gKLnFuj",
-	"y0NhqBhhyNSgRg+1yhDERXpNHNKhS12TGmBfWWOyy08OQ3Ox3O2sk0YGFYNSpGnvKq1TaOXBi2X7eLyT",
-	"UNHVLj1OVS+rpftQJmua0qLR5qtKA/sUWcfkLprcbolu6nNIeBbjtcMp6aOQkXu96fYjKqCp00D3I9qi",
-	"S4lKhzCfuSNRym6l51VvP1rB/a3g0QhNaYQ+82FFU7LnqmzKDD2KN5xZxrDzUqlPX0/jlO6pSmxfaPx6",
-	"KeUeNNR5lUeh1Q411Z28jt+2a511Q6Rs3qg7/o05Llv+6SpV/81jbVb7mv+n0han5MoukacDQVrXAOzo",
-	"U984qCh72xK1wXDIikL6gyEce1ywPBA5Ayl4fElXVhhkgqOO6EUE4rBXjwYjG1s/LLC6CSYfcQhyRsT6",
-	"RhJbb/gGcxJU2Rh1lKon1fSlEMpvfQOYafbVI/WjztB/ATZWQt3zWOq/1U2P/55cXF2e/BvW9UycEflf",
-	"JXhIGtEye4QDYTVUogXNcPjPFdwtSZYROFW320qpl++kJLPYgMLns5macQo56mbXri69ECKSkqp7Ry3h",
-	"e+/EEliGQ/UswexbSFem1vCBqpReACbDZfa+yHCwhJNXp2cNAOaz2Wq1OsXq7Slli5mZymc/Xb5998vN",
-	"OznldCmSGNkxuATD+5hBenF1iXx0D4xrmM9Pz07PTnCcLfG5SohmkOKMoDn6Qb5RzdDGr5nJU2AW04V2",
-	"kzLKFSWlrCn5uAzRvG70RvYFmfWQjjbu0MyaXeLtBq1XZ2fDy5hxs2Y5ufDRa5dZ7cYgNe/caV6n56nw",
-	"0d9c9uxrGrA1Cs2/3PqI50mC2dpcRyxbsMu0uXe3bvQ4+UjgBVcXryQLbuV6mm2N2uEC+jhHuKiqlWgM",
-	"7bu1zk34vAcRLHWG9R4TZZjbRcFhdMoO9WFBtHvsx8hip0d/lDg2S3WPLY7dbo/HEsZrEIzAPXgMcGxq",
-	"gjgSwKq7AxuZp2qug5JoarIV53YleruQfEDyWQRTMEnFrQsK3h1ElIFHhCk5bxL5+yop30s0nbMfTbNW",
-	"qeU5kEyDpD0cItZludC6iiJPWV3JGCbbQ2lOilmA4/gOB98GafjWDLCyFPbl+S/9ONVDZt2r74XvNMm6",
-	"h+44o77DX9y2GP7D2T9atTIB38VMOQWNIlnb69tQ9i9L/XXj1uuz1xPtUlKs2cvz+vzVROvXRUblmuGY",
-	"/AF1Uq0W2Am2uuxvxNtgSUuZq7uDhSqRRySF0E79OQh4WTodNqzq/ZTi/Xiyd11fQlMksRn22LJ3SIEw",
-	"TFI4WxfxHEWiriMMOnm6S2dn5ne+heBgqNpfNXCcYn2fwGFG55sVznPs70t0JNnp2Gx1PCnR/GH7tJ5G",
-	"zunOTe1Y4zi2HOtFyfNSZMwlosIfcJ+tbxmM8Z7bn0IoRhN3Gud5NE9ev3rl4Dy1umem46Wmo4e9FFZV",
-	"arHNw1rvZw9l3qnQSaQYBHSZ+6N6XjJ3NzNgfdBmnMq0uild2dq9nrUnY/URsnWq1ds8HVs1Azzs8QwC",
-	"EpFgkLd+vx2/WdLVgfjXo5UvkwnGTrrwIMt7eGDded+fCzva1/Z9+5drX0dJwIGtsqa+i+j0muZZ1Rzq",
-	"YKDfM5qYCsbTilj3UyvFE5r7ZyFl56/cNmxcFJpQzNQ9bQ/rVG/EaLLrMVG5+6q3ei8R8o8Bwmgb2+xs",
-	"f/lHZhzrzvHqcpq65jd8evZGGFprFH0+0cNauOprB0cL1+d593114PCHsIantI6bBbDPfbtS34WoZOAo",
-	"fS/nfH0e7p+SuwxYom8ybXIBTWfZjEMcDebmZExX942OKr82Lr8+o2RU62JsebUmA8ZpimMPBwHNU7tm",
-	"WPbibYm+bHqNCqHsz3MVk1H9r5amMhqxpjnz6Cqt+E1S3dZluoc6vLV1o7rJ1KscqsK4j3b0VOIPrRtV",
-	"ER2n6ptMCREQ2vfNhkhWxY+DTv84f//owffwcUrn/REz/Dlvdszo75Zuye8bx2dker/6Sl4xlq7H5H6V",
-	"3Dftsi32Vdo+ezC9pQ6J/VHubP3Z7WNaf7q0fj9XNyT1D8K7rir+STL6Q+Tf4FFOwoBRrujLN6d/jly+",
-	"syW2mi22G+T3jCbjakX7ClfnC6XHPMOB8vj6u30qkb/TwVB69CM7dywBOmbxRx+Qk7b6PJMsvjZgjTT+",
-	"4JG5IYkvyfOJHta6HbOoLzmHry3jJvEbTuHXlzSPsnfM4O/i9Gmpq1P4A55fs1n3obqM+OVWHkHlLUbz",
-	"T19+/HIrJUr3/GpRbF4iFGx9Wl4knOGMzO7PUXFb/C8AAP//",
+	"7D1rb9u4sn9F0L0flThpey8O8umk3ban2O42p2kWByiCgpHGNltJ1JJUEm/g/37Ah96kTcnyI11/SyRS",
+	"HM6bw5nxkx+SJCMppJz5F09+hihKgAOV/13mfP6GRHAlnooHEbCQ4oxjkvoX8rUXkgj8wMfiwZ850IUf",
+	"+ClKwL/w9SsWziFBYjpfZOI54xSnM3+5DOQnrii5xxFQ2yqphyNIOZ5ioN6UUI/PwUNi7UzPLNbPEJ9X",
+	"y9feUvgzxxQi/4LTHFaAFPiPJ/CIkiwWT2eYz/M7X8N5zRFfiQomBlhwUbxbhYz3lOSZbQFvJt7WUUGo",
+	"x+J8Zt68HP0NRxtsXnzh5NwXT2fkRH9XwvjhFzH4dyIACZGA0Ap1WhtUA94MdH3wANgrKOugdYG9SSmg",
+	"yALypzReeDFm3MvlsMYOmIW6amiDvBFMUR5z/2KKYgZBAe8dITGgtIVssbll4F+hGU5nb0iecgt08p0U",
+	"AE44ij0y9VAcewni4RynM49CSGjErAKZp9wMpULvSiDlRiooc8qITWbVEC+UYwKPox/AvIxCCBGkIXjk",
+	"HpQYk+mUAbeBK6evERq11EecYL4amFgMsaxUvDMg5vzsrMQLTjnMgLbwcn52VsLxSe5nNSAr91y+NICy",
+	"DhABxjUgGs7/LT5qgUKN8Ip1jcpKDlmD+GtC+RsS54lN+MUAyQVykG0pQrnDQp+o3UAU6xBaMwVttOp3",
+	"Bqz6iIV+4EOaJ/7FV/2fWMG/DVZrSDloGfg3zG68vJwBddbaYvBGSvt7RKClsQV0Qgcu1UeB8dckwqAM",
+	"/D3iiL4m0UL8F5KUQ8rFnzhBM5h8z2Am/qsWnxKaIIG0O5wiieAuvdTcLO09dRm0sPdFGHoJoic/6nHi",
+	"5VlMUBdDy8B/QwFxkAbKsCOUZbHW4pPvjKRN4DJKMqBco0Vhro1qgdY0j2N0V6hs8YQkmEOS8UUJhyTv",
+	"wNnLEivk7juE3IYV5QxEiCOBk1Du3I4TwQIbogSFHN/DepPhiiQUJTh1sJOu34ME4Xg4zaZ5HG9G9Qwx",
+	"9kBo1GD18mEw8KtCIWwClys/SS21np2kcAlu+oWSTYVMLGlW+NWyX9Wo215S0dhLnsY4/bFyL1dAkw33",
+	"kgFNjLq5xyYD9ZUN9irmSzems9ePZIZTcUbZdJ+9mHwl/5pwIEfWRMkVFyEFaWBRzAQexNlQ/BtauPgz",
+	"RJhCyEdACCc/IF2/OTXMdTtUw+fJaS47usmiv6npy7NoJU6uKJniGDbESoRnwHhtZ0fztFPzlCkqOlN9",
+	"VIdnUw/nyDJ792hW8AsDKvXmCB6N1EzrjYEadttnHy2dZ/Vpyt2M4NPYdhPYvB3jNnt6NYbd2rwauRzL",
+	"SMr0WTYU33mHcAzRW0oJ7bX3/6Uw9S/8/5lU8fCJessm9dihCXC1poAVHiHMOXhIwiKD1RQYyWkIMooc",
+	"U0DR4pJzFM53DeVnDYiHmYcUIB7SkAjgXqPoTeVH7Ra2m1R4OITivyDyHjCfew+UiNBRBZAG8bMKYeyL",
+	"wBmiDDwdR/GLU8RnzYejASS/apORCDjCMZPchZSw+PXzDBsEjkX4HaAMdPi0biZwyv//lW+KV6bwyI1a",
+	"RUc+3b6SUbg3fkWGxR0/IpSN3K2wMGzdbkvd6lcmCFGKFh3NV0STy1iuWshFB16KaGkMSn2Qae2Ux0oa",
+	"j0Zf971b9v1MKb+OXBo5g+klSfUh5UBTFF8DvQe6W311TRLwsAbAYxICDyQIErJ7FOPoizhc7kuPziAF",
+	"ijiIcLiERvytTslFuGJ0pSqO+1/UEl3Q3muAIn3olkYIHjNM5akD9B3i/k13SnjDbAugStu5Y6strzY0",
+	"SCUMGqh3JE/3hSYB0FSsL2DREYD65NFZy7CGm/UuDrZpY6YR6DHU/ijqunkh7mpCjCjqGpRDswXNzQ4x",
+	"CSYSMxuNVWrCCJTWGQkDUKBnOp/YulkSnsozqLa4LXmzxoNzSiHlVdhIyZyGSCYFjSFNRYJRLyGQM0yc",
+	"P5hn1cSgBs4QNm0kVElUfYYpBTbfrR3Wi660w9d5GAJjvwFjaAY7My9XMcKpx9TiXqJXXwa+3Mx+3ZUU",
+	"pIsn8KRV4BdCPiI6g12bYHky9u5ItBCuASfEiwUYKk8CzWD3h5ebgk5tiet7/jN+xnCNxfqdIYTGYpxQ",
+	"keCQi0WEOMrveCiNakeK8gTK9oFEoD/XKbC6nF23762eGKuIZ0nj0VWJ3sR6fzSV4BRwHIzH+RxCQ7uM",
+	"CpXxoD/U0RmTvR3ky8N7LSb6B1A8XWzFIKpPm0D6DTiSVwZkWiWI64jCssifK7PavxT39k1uVY4G+4aa",
+	"zCY2eMJxom6QUCRyhFv5Tz1vwcq8gdWfc80jCPwacjq7Uvk80fZ3Vb/b67excqZpb++LYHSL4iSCWPAg",
+	"hYwCg5R75Z3TuPtfBj6OzBeWVZ6lyjPf8MpVn2vW4m8XCRmBr65NN0GcYHXM47JywDeQt6FsLp5Mni4O",
+	"veKuTxoqGctU7tF9qQJZh/BqmLMar7SpyclJquNGF5sGzHPEc1YbXVgX26W2RlMDGwZsXVUsslYcCnYK",
+	"rLkNFvLVCgDKNAaHoTmf9zOaQmv5SysXKdy7cusYYv78E3028etHIYOrptxOeklNg+5L542T46LVwVUp",
+	"wjZNsFp92rVCM+a8PZu53pC1OeMHTh1mNlXy2rFiwPY9IApToJCGI5rwUY2w5hnPwcqoQKULQ7VrMdu5",
+	"kyyL0cKJSBHF90CdhuKQpE4D3R3SGpKKrXQQc1MwXOvGtR2/0Udpcb9pdkrvFhxYg6LFadQCaO10OlSw",
+	"EvT4bZN1xXyFiqFfcCRG4G+yTI2UilxGOjoyd5Gbf1jJoT+Bq7V3T+noqIzpqNwwm8LUvOcqbNKcbMUf",
+	"yWpGrfNSio/h+ajmV2ZMXKr9GTHlHuqo4sZbwVWPBMBeJ5Nv66Wu1mKgyDSuinW1Oi6qdclDKv/Xj5Va",
+	"vQ22QcYe9wV94mUOCGlV8NZjZt9ksXCJ2dsWq1ljLrVQhzniIvLGOM1DnlNZdsvm5KEWa9ERmA7rTTHE",
+	"kVGOrOGTunzUwOqGxQOfQZhTzBfXAtlqwdeI4bCMIUtTKp+U0+ecy7Pta0AUaHOketQZ+i9AWksIG+3P",
+	"1b9lkfZ/Ti6vPpz8CrUSZpThX0GHpXE6JUXMG4WS3bSp82ckQ9E/H+BujrMMw2kE1Vffi3eCk2msQWEX",
+	"k4mccQq5370TuPrgRTDFKS5TzeUnAu8tnwPNUCSfJYj+iMiDvkh/T3xxWxeCjsvrtS8zkU528uL0rAHA",
+	"xWTy8PBwiuTbU0JnEz2VTT5+ePP29+u3YsrpnCexXw/0CTC8Txmkl1cf/MC/B8oUzOenZ6dnJyjO5uhc",
+	"zCAZpCjD/oX/UryRpX/ar5kIKzCJRSKg+DcjKiojeE119Yj8i6qs0a/Xti9sMtoof580ayLb1QQvzs7s",
+	"n9HjJs08xWXgv3KZ1c5il/POneZ1EvSXgf9/LmuaslHrEuVffL0NfJYnCaIL3c+mKDgszzJ3i0ZCfuBz",
+	"NGOyZ4Igwa34niJbIzFmBibKYcbLVBx/CO67iTyr9vMOeDhX90L3CEvF3M54sW+nqMe0M2K9onQIL3Yq",
+	"UgexYzMPZdvs2E0j3hYzfgZOMdyDRwHFOuEFTTnQslJ2JfFkQpGVE3XCUUm5vkhvZ0ntEX01hEmYhOBW",
+	"16DeHUwJBQ9znU+1iuXvy6tEI9LUTeNgnLUuiA8BZQok5eFgvijSIWqF18LKSkSuQNtToU6WkxDF8R0K",
+	"f1hx+EYPqEWb6t3Xvpr3VA2ZdHunLQOnSbVGZo4zqiZwy9sWwV+e/aN1w8/hkU+kU9C42l/b7aXKaSvy",
+	"2KqKgFdnr0ZapcBYM0n81fmLkb5fpUZI1wzF+C+ogqMVw46w1AdzhccKTVrwXFXKxmUK0BSnENVDuA4M",
+	"ri3YCsUq34/J3tvjvc9VywWJkjrBts17+2SIIl+TN9tOOLJEdddodfJUvmJv4nfamDkoqnZDMscptdZi",
+	"DjM67eac59RbwzlPqnfZc59UNRBc3g6xz60kUykDL9dPM5QijWeglQcvmh1WHvysYK6CN3Vp/TKw+Om1",
+	"FmFD3PR2h7HlYOSO46UPpsmrFy8cvLRWcuF4tFR49JCXwkMZw2zTsFIwk6ciwLVU0aoYOHSJ+4t8XhC3",
+	"n76ptV4dJjKtmgRXsnabFmxI2LNXTlNr1XnjkVURwEMeyyAU18tW2gZmg3E9Jw97op9BKp8nEbSedKFB",
+	"lhtoUGsltTkVeurXdhur56tfB3HAnrWywr4L6xhV80R16nTU0Krz6FHON1W2vGqRSqYutHNwjcaiTU/p",
+	"rzWj/bsJ/vlLl+huvaBvTLEXDXVlsEvxkapMH6gEysIZBx3wjpJE35fultO6bUyXO/T5DoPjXrgt2Oh3",
+	"MSLTyRZmHlIXS1NKkr6+YhlcuNEVl8NZKDiGIw4/HNEsSHz+DnocFzW+WsRkW5yeRluJp8TPF7JfVVp2",
+	"HDyqUtM539T5b/8uv4KnUMOc9D0sXsnejCUPHLnv+RjywzhsSr7LgCaqAH2Vr6kTZp2PmbrUoTzMDEox",
+	"aXSvOYg4uPHctyA59USWV1XxV6CweLLu5NdF146Pb0Zs714KD+4o5kTbuoB0+nOtysDqdKDqrcNtv6x1",
+	"dJF36CKv7Bd3QPd3Qm01+NNZe1k5fFI0OrOl5qHoMo4trD6Or7Bv3P6G6A95oliLWg8xT/9UXR8UV93k",
+	"jLpEi/1YKHZojHdgLG1tgjcCez+1fhVx6cDwBgxupNdHVk0/wzFeyVyT5JsIHIN4ahUwcTFaVYL/JJ6s",
+	"Ep9Wj8aifU8GlBGRyoTC4lcrjR6t/Qqzjq9B95D1n455zh7tQRz0OnKBU1WEpWt9VspG2S3JKBwyH3gT",
+	"6TDkze9bNsqUd1F7kcrWYRDVe1rZUJYXRVZWPVKURPfHU7N/44GZ4PUdFCuUyTElwtbVqQy7YDheGezz",
+	"PDTmbcEWExiLbqEVYwJdG6nRkdaB2YvlT2Mth+L1mLtY5i7qsuMW+Uq1MnnSNboOeYuD4ufVLw8fsxbH",
+	"y1o0UzVYZVL3QLuuKP4kCYs29K/w9UchwKBDwvNXpz9HqqKzJna+P5KNS4Zlwh1F2y1H0Srpa7yfkciy",
+	"4wuu5yvsh5ye6C74VbXaesEXqYnDcuA3tSqd3yM9ZjTsKTVR/faezE3s5REWMYOBpY81BjomJh5+lGHU",
+	"WskDSUxUmrKRmdjPVCvZFOj5QvarRo+JYc85LVGpYE76nQlVVmLJAkfeOyYl9jpWKq6rshItLmazrcJT",
+	"2Tbu662wQEW/Of2falP39VZwlOrOoFix2e6N08Vp0fJtgjI8uT/3l7fL/w4A",
 }
 
 // decodeSpec returns the embedded OpenAPI spec as raw JSON bytes,
@@ -17,8 +17,9 @@ import (
 func (a *API) ListGroups(w http.ResponseWriter, r *http.Request, params ListGroupsParams) {
 	ctx := r.Context()
 	sort, order, limit, offset, search := listGroupsSorting(params)
+	cursor, count := toCursorParams(params.Cursor, params.Count)
 
-	records, count, err := a.storage.WithPrincipal(
+	records, meta, err := a.storage.WithPrincipal(
 		current.GetUser(ctx),
 	).Groups.List(
 		ctx,
@@ -28,9 +29,20 @@ func (a *API) ListGroups(w http.ResponseWriter, r *http.Request, params ListGrou
 			Limit:  limit,
 			Offset: offset,
 			Search: search,
+			Cursor: cursor,
+			Total:  count,
 		},
 	)
 
+	if errors.Is(err, store.ErrInvalidCursor) {
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Invalid paging cursor"),
+			Status:  ToPtr(http.StatusBadRequest),
+		})
+
+		return
+	}
+
 	if err != nil {
 		log.Error().
 			Err(err).
@@ -50,8 +62,12 @@ func (a *API) ListGroups(w http.ResponseWriter, r *http.Request, params ListGrou
 		payload[id] = a.convertGroup(record)
 	}
 
+	total, next, prev := fromListMeta(meta, count)
+
 	render.JSON(w, r, GroupsResponse{
-		Total:  count,
+		Total:  total,
+		Next:   next,
+		Prev:   prev,
 		Limit:  limit,
 		Offset: offset,
 		Groups: payload,
@@ -255,8 +271,9 @@ func (a *API) ListGroupUsers(w http.ResponseWriter, r *http.Request, _ GroupID,
 	ctx := r.Context()
 	record := a.GroupFromContext(ctx)
 	sort, order, limit, offset, search := listGroupUsersSorting(params)
+	cursor, count := toCursorParams(params.Cursor, params.Count)
 
-	records, count, err := a.storage.WithPrincipal(
+	records, meta, err := a.storage.WithPrincipal(
 		current.GetUser(ctx),
 	).Groups.ListUsers(
 		ctx,
@@ -267,11 +284,22 @@ func (a *API) ListGroupUsers(w http.ResponseWriter, r *http.Request, _ GroupID,
 				Limit:  limit,
 				Offset: offset,
 				Search: search,
+				Cursor: cursor,
+				Total:  count,
 			},
 			GroupID: record.ID,
 		},
 	)
 
+	if errors.Is(err, store.ErrInvalidCursor) {
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Invalid paging cursor"),
+			Status:  ToPtr(http.StatusBadRequest),
+		})
+
+		return
+	}
+
 	if err != nil {
 		log.Error().
 			Err(err).
@@ -292,8 +320,12 @@ func (a *API) ListGroupUsers(w http.ResponseWriter, r *http.Request, _ GroupID,
 		payload[id] = a.convertGroupUser(record)
 	}
 
+	total, next, prev := fromListMeta(meta, count)
+
 	render.JSON(w, r, GroupUsersResponse{
-		Total:  count,
+		Total:  total,
+		Next:   next,
+		Prev:   prev,
 		Limit:  limit,
 		Offset: offset,
 		Group:  ToPtr(a.convertGroup(record)),
@@ -586,6 +618,7 @@ func (a *API) convertGroup(record *model.Group) Group {
 		ID:        ToPtr(record.ID),
 		Slug:      ToPtr(record.Slug),
 		Name:      ToPtr(record.Name),
+		Profile:   ToPtr(a.groupAvatarFor(record)),
 		CreatedAt: ToPtr(record.CreatedAt),
 		UpdatedAt: ToPtr(record.UpdatedAt),
 	}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/drexedam/gravatar"
+	"github.com/gopad/gopad-api/pkg/model"
 )
 
 func gravatarFor(email string) string {
@@ -43,6 +44,44 @@ func toPageParams(sort *SortColumnParam, limit *PagingLimitParam, offset *Paging
 	return sortResult, limitResult, offsetResult, searchResult
 }
 
+func toCursorParams(cursor *PagingCursorParam, count *PagingCountParam) (string, bool) {
+	cursorResult := ""
+
+	if cursor != nil {
+		cursorResult = string(FromPtr(cursor))
+	}
+
+	countResult := true
+
+	if count != nil {
+		countResult = bool(FromPtr(count))
+	}
+
+	return cursorResult, countResult
+}
+
+func fromListMeta(meta model.ListMeta, count bool) (*int64, *string, *string) {
+	var (
+		total *int64
+		next  *string
+		prev  *string
+	)
+
+	if count {
+		total = ToPtr(meta.Total)
+	}
+
+	if meta.Next != "" {
+		next = ToPtr(meta.Next)
+	}
+
+	if meta.Prev != "" {
+		prev = ToPtr(meta.Prev)
+	}
+
+	return total, next, prev
+}
+
 // ToPtr transform input to a pointer.
 func ToPtr[T any](v T) *T {
 	return &v
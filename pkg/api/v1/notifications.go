@@ -0,0 +1,219 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/gopad/gopad-api/pkg/middleware/current"
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/gopad/gopad-api/pkg/store"
+	"github.com/rs/zerolog/log"
+)
+
+// ListProfileNotifications implements the v1.ServerInterface.
+func (a *API) ListProfileNotifications(w http.ResponseWriter, r *http.Request, params ListProfileNotificationsParams) {
+	ctx := r.Context()
+	principal := current.GetUser(ctx)
+	sort, order, limit, offset, unread := listProfileNotificationsSorting(params)
+	cursor, count := toCursorParams(params.Cursor, params.Count)
+
+	records, meta, err := a.storage.Notifications.List(
+		ctx,
+		model.NotificationParams{
+			ListParams: model.ListParams{
+				Sort:   sort,
+				Order:  order,
+				Limit:  limit,
+				Offset: offset,
+				Cursor: cursor,
+				Total:  count,
+			},
+			UserID: principal.ID,
+			Unread: unread,
+		},
+	)
+
+	if errors.Is(err, store.ErrInvalidCursor) {
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Invalid paging cursor"),
+			Status:  ToPtr(http.StatusBadRequest),
+		})
+
+		return
+	}
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("action", "ListProfileNotifications").
+			Str("user", principal.ID).
+			Msg("Failed to load notifications")
+
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Failed to load notifications"),
+			Status:  ToPtr(http.StatusInternalServerError),
+		})
+
+		return
+	}
+
+	payload := make([]ProfileNotification, len(records))
+	for id, record := range records {
+		payload[id] = a.convertProfileNotification(record)
+	}
+
+	total, next, prev := fromListMeta(meta, count)
+
+	render.JSON(w, r, ProfileNotificationsResponse{
+		Total:         total,
+		Next:          next,
+		Prev:          prev,
+		Limit:         limit,
+		Offset:        offset,
+		Notifications: payload,
+	})
+}
+
+// UnreadProfileNotifications implements the v1.ServerInterface.
+func (a *API) UnreadProfileNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	principal := current.GetUser(ctx)
+
+	unread, err := a.storage.Notifications.Unread(
+		ctx,
+		principal.ID,
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("action", "UnreadProfileNotifications").
+			Str("user", principal.ID).
+			Msg("Failed to count notifications")
+
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Failed to count notifications"),
+			Status:  ToPtr(http.StatusInternalServerError),
+		})
+
+		return
+	}
+
+	render.JSON(w, r, ProfileNotificationsUnreadResponse{
+		Unread: unread,
+	})
+}
+
+// ReadProfileNotification implements the v1.ServerInterface.
+func (a *API) ReadProfileNotification(w http.ResponseWriter, r *http.Request, notificationID NotificationID) {
+	ctx := r.Context()
+	principal := current.GetUser(ctx)
+
+	record, err := a.storage.Notifications.Read(
+		ctx,
+		principal.ID,
+		notificationID,
+	)
+
+	if errors.Is(err, store.ErrNotificationNotFound) {
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Failed to find notification"),
+			Status:  ToPtr(http.StatusNotFound),
+		})
+
+		return
+	}
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("action", "ReadProfileNotification").
+			Str("user", principal.ID).
+			Str("notification", notificationID).
+			Msg("Failed to mark notification as read")
+
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Failed to mark notification as read"),
+			Status:  ToPtr(http.StatusInternalServerError),
+		})
+
+		return
+	}
+
+	render.JSON(w, r, ProfileNotificationResponse(
+		a.convertProfileNotification(record),
+	))
+}
+
+// ReadAllProfileNotifications implements the v1.ServerInterface.
+func (a *API) ReadAllProfileNotifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	principal := current.GetUser(ctx)
+
+	if err := a.storage.Notifications.ReadAll(
+		ctx,
+		principal.ID,
+	); err != nil {
+		log.Error().
+			Err(err).
+			Str("action", "ReadAllProfileNotifications").
+			Str("user", principal.ID).
+			Msg("Failed to mark notifications as read")
+
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Failed to mark notifications as read"),
+			Status:  ToPtr(http.StatusInternalServerError),
+		})
+
+		return
+	}
+
+	a.RenderNotify(w, r, Notification{
+		Message: ToPtr("Successfully marked notifications as read"),
+		Status:  ToPtr(http.StatusOK),
+	})
+}
+
+func (a *API) convertProfileNotification(record *model.Notification) ProfileNotification {
+	result := ProfileNotification{
+		ID:        ToPtr(record.ID),
+		Kind:      ToPtr(record.Kind),
+		Message:   ToPtr(record.Message),
+		CreatedAt: ToPtr(record.CreatedAt),
+		UpdatedAt: ToPtr(record.UpdatedAt),
+	}
+
+	if record.Reference != "" {
+		result.Reference = ToPtr(record.Reference)
+	}
+
+	if !record.ReadAt.IsZero() {
+		result.ReadAt = ToPtr(record.ReadAt)
+	}
+
+	return result
+}
+
+func listProfileNotificationsSorting(request ListProfileNotificationsParams) (string, string, int64, int64, bool) {
+	sort, limit, offset, _ := toPageParams(
+		request.Sort,
+		request.Limit,
+		request.Offset,
+		nil,
+	)
+
+	order := ""
+
+	if request.Order != nil {
+		order = string(FromPtr(request.Order))
+	}
+
+	unread := false
+
+	if request.Unread != nil {
+		unread = bool(FromPtr(request.Unread))
+	}
+
+	return sort, order, limit, offset, unread
+}
@@ -101,6 +101,10 @@ func (a *API) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		record.Fullname = FromPtr(body.Fullname)
 	}
 
+	if body.Digest != nil {
+		record.Digest = FromPtr(body.Digest)
+	}
+
 	if err := a.storage.Users.Update(
 		r.Context(),
 		record,
@@ -154,7 +158,8 @@ func (a *API) convertProfile(record *model.User) Profile {
 		Username:  ToPtr(record.Username),
 		Email:     ToPtr(record.Email),
 		Fullname:  ToPtr(record.Fullname),
-		Profile:   ToPtr(gravatarFor(record.Email)),
+		Profile:   ToPtr(a.avatarFor(record)),
+		Digest:    ToPtr(record.Digest),
 		Active:    ToPtr(record.Active),
 		Admin:     ToPtr(record.Admin),
 		CreatedAt: ToPtr(record.CreatedAt),
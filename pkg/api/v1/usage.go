@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/go-chi/render"
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/rs/zerolog/log"
+)
+
+// ShowUsage implements the v1.ServerInterface.
+func (a *API) ShowUsage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	quota := a.storage.Uploads.Quota()
+
+	users, err := a.storage.Uploads.UserUsage(ctx)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("action", "ShowUsage").
+			Msg("Failed to load user usage")
+
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Failed to load usage"),
+			Status:  ToPtr(http.StatusInternalServerError),
+		})
+
+		return
+	}
+
+	groups, err := a.storage.Uploads.GroupUsage(ctx)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("action", "ShowUsage").
+			Msg("Failed to load group usage")
+
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Failed to load usage"),
+			Status:  ToPtr(http.StatusInternalServerError),
+		})
+
+		return
+	}
+
+	payload := UsageResponse{
+		Users:  make([]Usage, len(users)),
+		Groups: make([]Usage, len(groups)),
+	}
+
+	for id, record := range users {
+		payload.Users[id] = a.convertUsage(record, quota.User)
+	}
+
+	for id, record := range groups {
+		payload.Groups[id] = a.convertUsage(record, quota.Group)
+	}
+
+	render.JSON(w, r, payload)
+}
+
+func (a *API) convertUsage(record *model.Usage, limit config.QuotaLimit) Usage {
+	result := Usage{
+		ID:      ToPtr(record.ID),
+		Name:    ToPtr(record.Name),
+		Bytes:   ToPtr(record.Bytes),
+		Objects: ToPtr(record.Objects),
+	}
+
+	if limit.Bytes > 0 {
+		result.MaxBytes = ToPtr(limit.Bytes)
+	}
+
+	if limit.Objects > 0 {
+		result.MaxObjects = ToPtr(limit.Objects)
+	}
+
+	return result
+}
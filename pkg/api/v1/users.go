@@ -17,8 +17,9 @@ import (
 func (a *API) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsersParams) {
 	ctx := r.Context()
 	sort, order, limit, offset, search := listUsersSorting(params)
+	cursor, count := toCursorParams(params.Cursor, params.Count)
 
-	records, count, err := a.storage.WithPrincipal(
+	records, meta, err := a.storage.WithPrincipal(
 		current.GetUser(ctx),
 	).Users.List(
 		ctx,
@@ -28,9 +29,20 @@ func (a *API) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsers
 			Limit:  limit,
 			Offset: offset,
 			Search: search,
+			Cursor: cursor,
+			Total:  count,
 		},
 	)
 
+	if errors.Is(err, store.ErrInvalidCursor) {
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Invalid paging cursor"),
+			Status:  ToPtr(http.StatusBadRequest),
+		})
+
+		return
+	}
+
 	if err != nil {
 		log.Error().
 			Err(err).
@@ -50,8 +62,12 @@ func (a *API) ListUsers(w http.ResponseWriter, r *http.Request, params ListUsers
 		payload[id] = a.convertUser(record)
 	}
 
+	total, next, prev := fromListMeta(meta, count)
+
 	render.JSON(w, r, UsersResponse{
-		Total:  count,
+		Total:  total,
+		Next:   next,
+		Prev:   prev,
 		Limit:  limit,
 		Offset: offset,
 		Users:  payload,
@@ -287,8 +303,9 @@ func (a *API) ListUserGroups(w http.ResponseWriter, r *http.Request, _ UserID, p
 	ctx := r.Context()
 	record := a.UserFromContext(ctx)
 	sort, order, limit, offset, search := listUserGroupsSorting(params)
+	cursor, count := toCursorParams(params.Cursor, params.Count)
 
-	records, count, err := a.storage.WithPrincipal(
+	records, meta, err := a.storage.WithPrincipal(
 		current.GetUser(ctx),
 	).Users.ListGroups(
 		ctx,
@@ -299,11 +316,22 @@ func (a *API) ListUserGroups(w http.ResponseWriter, r *http.Request, _ UserID, p
 				Limit:  limit,
 				Offset: offset,
 				Search: search,
+				Cursor: cursor,
+				Total:  count,
 			},
 			UserID: record.ID,
 		},
 	)
 
+	if errors.Is(err, store.ErrInvalidCursor) {
+		a.RenderNotify(w, r, Notification{
+			Message: ToPtr("Invalid paging cursor"),
+			Status:  ToPtr(http.StatusBadRequest),
+		})
+
+		return
+	}
+
 	if err != nil {
 		log.Error().
 			Err(err).
@@ -324,8 +352,12 @@ func (a *API) ListUserGroups(w http.ResponseWriter, r *http.Request, _ UserID, p
 		payload[id] = a.convertUserGroup(record)
 	}
 
+	total, next, prev := fromListMeta(meta, count)
+
 	render.JSON(w, r, UserGroupsResponse{
-		Total:  count,
+		Total:  total,
+		Next:   next,
+		Prev:   prev,
 		Limit:  limit,
 		Offset: offset,
 		User:   ToPtr(a.convertUser(record)),
@@ -619,7 +651,7 @@ func (a *API) convertUser(record *model.User) User {
 		Username:  ToPtr(record.Username),
 		Email:     ToPtr(record.Email),
 		Fullname:  ToPtr(record.Fullname),
-		Profile:   ToPtr(gravatarFor(record.Email)),
+		Profile:   ToPtr(a.avatarFor(record)),
 		Active:    ToPtr(record.Active),
 		Admin:     ToPtr(record.Admin),
 		CreatedAt: ToPtr(record.CreatedAt),
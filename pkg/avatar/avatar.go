@@ -0,0 +1,97 @@
+package avatar
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+
+	// generally register the supported image formats.
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+const (
+	// maxDimension defines the maximum accepted width or height.
+	maxDimension = 8192
+
+	// maxPixels defines the maximum accepted width times height, it is
+	// checked before decoding to bound the memory of the decoded image.
+	maxPixels = 4096 * 4096
+)
+
+var (
+	// ErrUnsupportedFormat is returned for images which can't be decoded.
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+
+	// ErrTooLarge is returned for images with too large dimensions.
+	ErrTooLarge = errors.New("image dimensions are too large")
+
+	// ErrInvalidSize is returned for sizes which can't be rendered.
+	ErrInvalidSize = errors.New("invalid avatar size")
+)
+
+// Validate checks if the given size can be used to render avatars, it has to
+// be positive and must not exceed the maximum accepted dimension.
+func Validate(size int) error {
+	if size <= 0 || size > maxDimension {
+		return fmt.Errorf("%w: %d", ErrInvalidSize, size)
+	}
+
+	return nil
+}
+
+// Resize validates an uploaded image, crops it to a centered square and
+// scales it to the given size, the result is always encoded as PNG.
+func Resize(r io.Reader, size int) (*bytes.Buffer, error) {
+	if err := Validate(size); err != nil {
+		return nil, err
+	}
+
+	content, err := io.ReadAll(r)
+
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(content))
+
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	if cfg.Width > maxDimension || cfg.Height > maxDimension || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(content))
+
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+
+	crop := image.Rect(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+		bounds.Min.X+(bounds.Dx()-side)/2+side,
+		bounds.Min.Y+(bounds.Dy()-side)/2+side,
+	)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, crop, draw.Over, nil)
+
+	result := bytes.NewBuffer([]byte{})
+
+	if err := png.Encode(result, dst); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,95 @@
+package avatar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResize(t *testing.T) {
+	valid := &bytes.Buffer{}
+	require.NoError(t, png.Encode(valid, image.NewRGBA(image.Rect(0, 0, 64, 32))))
+
+	tests := []struct {
+		name    string
+		content []byte
+		err     error
+	}{
+		{name: "valid", content: valid.Bytes(), err: nil},
+		{name: "garbage", content: []byte("no image"), err: ErrUnsupportedFormat},
+		{name: "too wide", content: pngHeader(maxDimension+1, 1), err: ErrTooLarge},
+		{name: "too many pixels", content: pngHeader(5000, 5000), err: ErrTooLarge},
+		{name: "pixel limit", content: pngHeader(maxDimension, maxPixels/maxDimension), err: ErrUnsupportedFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Resize(bytes.NewReader(tt.content), 16)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+
+			img, err := png.Decode(result)
+			require.NoError(t, err)
+			assert.Equal(t, image.Rect(0, 0, 16, 16), img.Bounds())
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		err  error
+	}{
+		{name: "default", size: 256, err: nil},
+		{name: "maximum", size: maxDimension, err: nil},
+		{name: "zero", size: 0, err: ErrInvalidSize},
+		{name: "negative", size: -1, err: ErrInvalidSize},
+		{name: "too large", size: maxDimension + 1, err: ErrInvalidSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.size)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+// pngHeader builds a PNG which only contains the header, that's enough for
+// the dimension checks without allocating the image itself.
+func pngHeader(width, height uint32) []byte {
+	result := &bytes.Buffer{}
+	result.WriteString("\x89PNG\r\n\x1a\n")
+
+	data := make([]byte, 13)
+	binary.BigEndian.PutUint32(data[0:4], width)
+	binary.BigEndian.PutUint32(data[4:8], height)
+	data[8] = 8
+	data[9] = 6
+
+	chunk := append([]byte("IHDR"), data...)
+
+	_ = binary.Write(result, binary.BigEndian, uint32(len(data)))
+	result.Write(chunk)
+	_ = binary.Write(result, binary.BigEndian, crc32.ChecksumIEEE(chunk))
+
+	return result.Bytes()
+}
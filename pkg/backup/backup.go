@@ -0,0 +1,135 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+const (
+	// Version defines the current version of the archive format.
+	Version = 1
+
+	manifestName = "manifest.json"
+	tablesPrefix = "tables/"
+	tablesSuffix = ".jsonl"
+	uploadPrefix = "uploads/"
+	batchSize    = 100
+)
+
+var (
+	// ErrMissingManifest is returned when an archive doesn't start with a manifest.
+	ErrMissingManifest = errors.New("archive is missing the manifest")
+
+	// ErrUnsupportedVersion is returned when an archive got a newer format.
+	ErrUnsupportedVersion = errors.New("unsupported archive version")
+
+	// ErrUnknownTable is returned when an archive contains an unknown table.
+	ErrUnknownTable = errors.New("unknown table within archive")
+
+	// ErrInvalidOrder is returned when an archive contains tables after uploads.
+	ErrInvalidOrder = errors.New("tables must precede uploads within archive")
+
+	// ErrNotEmpty is returned when the target database already contains records.
+	ErrNotEmpty = errors.New("target database is not empty")
+
+	// Models defines all exported models, ordered by their dependencies.
+	Models = []any{
+		(*model.User)(nil),
+		(*model.Group)(nil),
+		(*model.UserAuth)(nil),
+		(*model.UserGroup)(nil),
+		(*model.UserToken)(nil),
+		(*model.Upload)(nil),
+		(*model.Notification)(nil),
+	}
+)
+
+// Manifest defines the metadata stored within every archive.
+type Manifest struct {
+	Version   int              `json:"version"`
+	Driver    string           `json:"driver"`
+	CreatedAt time.Time        `json:"created_at"`
+	Tables    map[string]int64 `json:"tables"`
+	Uploads   int64            `json:"uploads"`
+}
+
+// Options defines the available options for export and import.
+type Options struct {
+	Uploads  bool
+	Truncate bool
+}
+
+func tables(db *bun.DB) []*schema.Table {
+	result := make([]*schema.Table, 0, len(Models))
+
+	for _, m := range Models {
+		result = append(
+			result,
+			db.Table(reflect.TypeOf(m).Elem()),
+		)
+	}
+
+	return result
+}
+
+func tableByName(db *bun.DB, name string) (*schema.Table, error) {
+	for _, table := range tables(db) {
+		if table.Name == name {
+			return table, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownTable, name)
+}
+
+func encodeRow(table *schema.Table, strct reflect.Value) ([]byte, error) {
+	row := make(map[string]any, len(table.Fields))
+
+	for _, field := range table.Fields {
+		row[field.Name] = field.Value(strct).Interface()
+	}
+
+	return json.Marshal(row)
+}
+
+func decodeRow(table *schema.Table, content []byte) (map[string]any, error) {
+	raw := make(map[string]json.RawMessage, len(table.Fields))
+
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+
+	strct := reflect.New(table.Type).Elem()
+	row := make(map[string]any, len(table.Fields))
+
+	for _, field := range table.Fields {
+		val, ok := raw[field.Name]
+
+		if !ok {
+			continue
+		}
+
+		target := field.Value(strct)
+
+		if err := json.Unmarshal(val, target.Addr().Interface()); err != nil {
+			return nil, fmt.Errorf("failed to decode %s.%s: %w", table.Name, field.Name, err)
+		}
+
+		// Zero values of nullzero columns have been NULL before the export,
+		// otherwise they would be restored as empty values.
+		if field.NullZero && field.HasZeroValue(strct) {
+			row[field.Name] = nil
+		} else {
+			row[field.Name] = target.Interface()
+		}
+	}
+
+	return row, nil
+}
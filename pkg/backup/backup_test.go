@@ -0,0 +1,197 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/gopad/gopad-api/pkg/store"
+	"github.com/gopad/gopad-api/pkg/upload"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+func TestRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source, sourceUploads := testBackend(t)
+
+	require.NoError(t, source.Users.Create(ctx, &model.User{
+		Username: "jdoe",
+		Password: "p455w0rd",
+		Email:    "jdoe@example.com",
+		Active:   true,
+	}))
+
+	require.NoError(t, sourceUploads.Upload(ctx, "avatars/jdoe.txt", strings.NewReader("avatar"), 6, "text/plain"))
+
+	tests := []struct {
+		name    string
+		uploads bool
+		objects int
+	}{
+		{name: "with uploads", uploads: true, objects: 1},
+		{name: "without uploads", uploads: false, objects: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := &bytes.Buffer{}
+
+			exported, err := Export(ctx, source.Handle(), sourceUploads, archive, Options{Uploads: tt.uploads})
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), exported.Tables["users"])
+			assert.Equal(t, int64(tt.objects), exported.Uploads)
+
+			target, targetUploads := testBackend(t)
+
+			imported, err := Import(ctx, target.Handle(), targetUploads, bytes.NewReader(archive.Bytes()), Options{Uploads: tt.uploads})
+			require.NoError(t, err)
+			assert.Equal(t, exported.Tables, imported.Tables)
+
+			user, err := target.Users.Show(ctx, "jdoe")
+			require.NoError(t, err)
+			assert.Equal(t, "jdoe@example.com", user.Email)
+
+			objects, err := targetUploads.List(ctx, "")
+			require.NoError(t, err)
+			assert.Len(t, objects, tt.objects)
+		})
+	}
+}
+
+func TestRoundTripNulls(t *testing.T) {
+	ctx := context.Background()
+	source, sourceUploads := testBackend(t)
+
+	user := &model.User{
+		Username: "jdoe",
+		Password: "p455w0rd",
+		Email:    "jdoe@example.com",
+		Active:   true,
+	}
+
+	require.NoError(t, source.Users.Create(ctx, user))
+
+	_, err := source.Handle().NewInsert().
+		Model(&model.Notification{
+			UserID:  user.ID,
+			Kind:    model.NotificationKindGroupAttached,
+			Message: "unread",
+		}).
+		Exec(ctx)
+
+	require.NoError(t, err)
+
+	_, err = source.Handle().NewInsert().
+		Model(&model.Upload{
+			Path: "avatars/deleted/avatar.png",
+			Size: 6,
+		}).
+		Exec(ctx)
+
+	require.NoError(t, err)
+
+	archive := &bytes.Buffer{}
+
+	_, err = Export(ctx, source.Handle(), sourceUploads, archive, Options{})
+	require.NoError(t, err)
+
+	target, targetUploads := testBackend(t)
+
+	_, err = Import(ctx, target.Handle(), targetUploads, bytes.NewReader(archive.Bytes()), Options{})
+	require.NoError(t, err)
+
+	unread, err := target.Notifications.Unread(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), unread)
+
+	tests := []struct {
+		name   string
+		model  any
+		column string
+	}{
+		{name: "avatar", model: (*model.User)(nil), column: "avatar"},
+		{name: "read at", model: (*model.Notification)(nil), column: "read_at"},
+		{name: "upload owner", model: (*model.Upload)(nil), column: "user_id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := target.Handle().NewSelect().
+				Model(tt.model).
+				Where("? IS NULL", bun.Ident(tt.column)).
+				Count(ctx)
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, count)
+		})
+	}
+}
+
+func TestImportNotEmpty(t *testing.T) {
+	ctx := context.Background()
+	source, sourceUploads := testBackend(t)
+
+	require.NoError(t, sourceUploads.Upload(ctx, "avatars/jdoe.txt", strings.NewReader("avatar"), 6, "text/plain"))
+
+	archive := &bytes.Buffer{}
+
+	_, err := Export(ctx, source.Handle(), sourceUploads, archive, Options{Uploads: true})
+	require.NoError(t, err)
+
+	target, targetUploads := testBackend(t)
+
+	require.NoError(t, target.Users.Create(ctx, &model.User{
+		Username: "existing",
+		Password: "p455w0rd",
+		Email:    "existing@example.com",
+	}))
+
+	_, err = Import(ctx, target.Handle(), targetUploads, bytes.NewReader(archive.Bytes()), Options{Uploads: true})
+	assert.ErrorIs(t, err, ErrNotEmpty)
+
+	objects, err := targetUploads.List(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+}
+
+func testBackend(t *testing.T) (*store.Store, upload.Upload) {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	uploads, err := upload.NewFileUpload(config.Upload{
+		Path: filepath.Join(dir, "uploads"),
+	})
+
+	require.NoError(t, err)
+
+	s, err := store.NewStore(
+		config.Database{
+			Driver: "sqlite3",
+			Name:   filepath.Join(dir, "gopad.sqlite3"),
+		},
+		config.Scim{},
+		config.Quota{},
+		nil,
+	)
+
+	require.NoError(t, err)
+
+	_, err = s.Open()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = s.Close()
+	})
+
+	_, _, err = s.Migrate(context.Background(), store.MigrateOptions{})
+	require.NoError(t, err)
+
+	return s, uploads
+}
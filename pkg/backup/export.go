@@ -0,0 +1,199 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gopad/gopad-api/pkg/upload"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// Export writes all tables and optionally all uploads into an archive.
+func Export(ctx context.Context, db *bun.DB, uploads upload.Upload, w io.Writer, opts Options) (*Manifest, error) {
+	manifest := &Manifest{
+		Version:   Version,
+		Driver:    db.Dialect().Name().String(),
+		CreatedAt: time.Now().UTC(),
+		Tables:    make(map[string]int64),
+	}
+
+	objects := make([]*upload.Object, 0)
+
+	if opts.Uploads && uploads != nil {
+		res, err := uploads.List(ctx, "")
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to list uploads: %w", err)
+		}
+
+		objects = res
+		manifest.Uploads = int64(len(objects))
+	}
+
+	gz := gzip.NewWriter(w)
+	defer func() { _ = gz.Close() }()
+
+	archive := tar.NewWriter(gz)
+	defer func() { _ = archive.Close() }()
+
+	if err := db.RunInTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	}, func(ctx context.Context, tx bun.Tx) error {
+		dumps := make([]*os.File, 0, len(Models))
+
+		defer func() {
+			for _, dump := range dumps {
+				_ = dump.Close()
+				_ = os.Remove(dump.Name())
+			}
+		}()
+
+		for _, table := range tables(db) {
+			dump, err := os.CreateTemp("", "gopad-export-*.jsonl")
+
+			if err != nil {
+				return err
+			}
+
+			dumps = append(dumps, dump)
+			count, err := exportTable(ctx, tx, table, dump)
+
+			if err != nil {
+				return fmt.Errorf("failed to export %s: %w", table.Name, err)
+			}
+
+			manifest.Tables[table.Name] = count
+		}
+
+		content, err := json.MarshalIndent(manifest, "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		if err := writeEntry(archive, manifestName, int64(len(content)), manifest.CreatedAt, bytes.NewReader(content)); err != nil {
+			return err
+		}
+
+		for i, table := range tables(db) {
+			stat, err := dumps[i].Stat()
+
+			if err != nil {
+				return err
+			}
+
+			if _, err := dumps[i].Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+
+			if err := writeEntry(archive, tablesPrefix+table.Name+tablesSuffix, stat.Size(), manifest.CreatedAt, dumps[i]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, object := range objects {
+		reader, err := uploads.Open(ctx, object.Key)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to open upload %s: %w", object.Key, err)
+		}
+
+		if err := writeEntry(archive, uploadPrefix+object.Key, object.Size, object.ModTime, reader); err != nil {
+			_ = reader.Close()
+			return nil, fmt.Errorf("failed to export upload %s: %w", object.Key, err)
+		}
+
+		_ = reader.Close()
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+func exportTable(ctx context.Context, tx bun.Tx, table *schema.Table, w io.Writer) (int64, error) {
+	writer := bufio.NewWriter(w)
+	order := make([]string, 0, len(table.PKs))
+
+	for _, pk := range table.PKs {
+		order = append(order, string(pk.SQLName))
+	}
+
+	total := int64(0)
+
+	for offset := 0; ; offset += batchSize {
+		records := reflect.New(reflect.SliceOf(reflect.PointerTo(table.Type)))
+
+		if err := tx.NewSelect().
+			Model(records.Interface()).
+			OrderExpr(strings.Join(order, ", ")).
+			Limit(batchSize).
+			Offset(offset).
+			Scan(ctx); err != nil {
+			return total, err
+		}
+
+		rows := records.Elem()
+
+		for i := 0; i < rows.Len(); i++ {
+			line, err := encodeRow(table, rows.Index(i).Elem())
+
+			if err != nil {
+				return total, err
+			}
+
+			if _, err := writer.Write(append(line, '\n')); err != nil {
+				return total, err
+			}
+
+			total++
+		}
+
+		if rows.Len() < batchSize {
+			break
+		}
+	}
+
+	return total, writer.Flush()
+}
+
+func writeEntry(archive *tar.Writer, name string, size int64, modified time.Time, content io.Reader) error {
+	if err := archive.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o600,
+		Size:    size,
+		ModTime: modified,
+	}); err != nil {
+		return err
+	}
+
+	if _, err := io.CopyN(archive, content, size); err != nil {
+		return err
+	}
+
+	return nil
+}
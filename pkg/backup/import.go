@@ -0,0 +1,204 @@
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/gopad/gopad-api/pkg/upload"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// Import restores all tables and optionally all uploads from an archive. The
+// uploads are only written after the tables got committed, that way a failed
+// import never leaves uploads behind without any records referencing them.
+func Import(ctx context.Context, db *bun.DB, uploads upload.Upload, r io.Reader, opts Options) (*Manifest, error) {
+	gz, err := gzip.NewReader(r)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+
+	defer func() { _ = gz.Close() }()
+
+	archive := tar.NewReader(gz)
+	header, err := archive.Next()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	if header.Name != manifestName {
+		return nil, ErrMissingManifest
+	}
+
+	manifest := &Manifest{}
+
+	if err := json.NewDecoder(archive).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if manifest.Version < 1 || manifest.Version > Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, manifest.Version)
+	}
+
+	var next *tar.Header
+
+	if err := db.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if opts.Truncate {
+			if err := truncateTables(ctx, db, tx); err != nil {
+				return err
+			}
+		} else {
+			if err := ensureEmpty(ctx, db, tx); err != nil {
+				return err
+			}
+		}
+
+		for {
+			header, err := archive.Next()
+
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			if err != nil {
+				return fmt.Errorf("failed to read archive: %w", err)
+			}
+
+			switch {
+			case strings.HasPrefix(header.Name, tablesPrefix):
+				table, err := tableByName(
+					db,
+					strings.TrimSuffix(
+						strings.TrimPrefix(
+							header.Name,
+							tablesPrefix,
+						),
+						tablesSuffix,
+					),
+				)
+
+				if err != nil {
+					return err
+				}
+
+				if err := importTable(ctx, tx, table, archive); err != nil {
+					return fmt.Errorf("failed to import %s: %w", table.Name, err)
+				}
+			case strings.HasPrefix(header.Name, uploadPrefix):
+				next = header
+				return nil
+			}
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := importUploads(ctx, uploads, archive, next, opts); err != nil {
+		return manifest, err
+	}
+
+	return manifest, nil
+}
+
+func importUploads(ctx context.Context, uploads upload.Upload, archive *tar.Reader, header *tar.Header, opts Options) error {
+	for header != nil {
+		switch {
+		case strings.HasPrefix(header.Name, tablesPrefix):
+			return fmt.Errorf("%w: %s", ErrInvalidOrder, header.Name)
+		case strings.HasPrefix(header.Name, uploadPrefix):
+			if opts.Uploads && uploads != nil {
+				key := strings.TrimPrefix(header.Name, uploadPrefix)
+
+				if err := uploads.Upload(ctx, key, archive, header.Size, ""); err != nil {
+					return fmt.Errorf("failed to import upload %s: %w", key, err)
+				}
+			}
+		}
+
+		next, err := archive.Next()
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		header = next
+	}
+
+	return nil
+}
+
+func importTable(ctx context.Context, tx bun.Tx, table *schema.Table, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		row, err := decodeRow(table, scanner.Bytes())
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.NewInsert().
+			Model(&row).
+			TableExpr(string(table.SQLName)).
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func ensureEmpty(ctx context.Context, db *bun.DB, tx bun.Tx) error {
+	for _, table := range tables(db) {
+		exists, err := tx.NewSelect().
+			TableExpr(string(table.SQLName)).
+			Exists(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		if exists {
+			return fmt.Errorf("%w: %s", ErrNotEmpty, table.Name)
+		}
+	}
+
+	return nil
+}
+
+func truncateTables(ctx context.Context, db *bun.DB, tx bun.Tx) error {
+	reversed := tables(db)
+	slices.Reverse(reversed)
+
+	for _, table := range reversed {
+		if _, err := tx.NewDelete().
+			TableExpr(string(table.SQLName)).
+			Where("1 = 1").
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,12 +2,16 @@ package command
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v7"
+	"github.com/gopad/gopad-api/pkg/backup"
 	"github.com/gopad/gopad-api/pkg/store"
+	"github.com/gopad/gopad-api/pkg/upload"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -67,6 +71,26 @@ var (
 		Short: "Create new migration",
 		Run:   dbCreateAction,
 	}
+
+	dbExportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export database and uploads",
+		Run:   dbExportAction,
+		Args:  cobra.NoArgs,
+	}
+
+	dbImportCmd = &cobra.Command{
+		Use:   "import",
+		Short: "Import database and uploads",
+		Run:   dbImportAction,
+		Args:  cobra.NoArgs,
+	}
+
+	defaultMigrateSteps   = 1
+	defaultCleanupUploads = false
+	defaultBackupFile     = "gopad-backup.tar.gz"
+	defaultBackupUploads  = false
+	defaultBackupTruncate = false
 )
 
 func init() {
@@ -79,6 +103,8 @@ func init() {
 	dbCmd.AddCommand(dbUnlockCmd)
 	dbCmd.AddCommand(dbStatusCmd)
 	dbCmd.AddCommand(dbCreateCmd)
+	dbCmd.AddCommand(dbExportCmd)
+	dbCmd.AddCommand(dbImportCmd)
 
 	dbCmd.PersistentFlags().String("database-driver", defaultDatabaseDriver, "Driver for the database")
 	viper.SetDefault("database.driver", defaultDatabaseDriver)
@@ -107,6 +133,29 @@ func init() {
 	dbCmd.PersistentFlags().StringToString("database-options", defaultDatabaseOptions, "Options for the database connection")
 	viper.SetDefault("database.options", defaultDatabaseOptions)
 	_ = viper.BindPFlag("database.options", serverCmd.PersistentFlags().Lookup("database-options"))
+
+	dbCmd.PersistentFlags().StringSlice("database-replicas", defaultDatabaseReplicas, "DSNs for read-only database replicas")
+	viper.SetDefault("database.replicas", defaultDatabaseReplicas)
+	_ = viper.BindPFlag("database.replicas", serverCmd.PersistentFlags().Lookup("database-replicas"))
+
+	dbCleanupCmd.Flags().Bool("uploads", defaultCleanupUploads, "Delete orphaned uploads")
+	dbCleanupCmd.Flags().Duration("grace", defaultCleanupGrace, "Minimum age before orphaned uploads get deleted")
+	dbCleanupCmd.Flags().Bool("dry-run", false, "Only report orphaned uploads without deleting them")
+
+	dbMigrateCmd.Flags().String("to", "", "Migrate up to and including this migration")
+	dbMigrateCmd.Flags().Bool("dry-run", false, "Print the statements without executing them")
+
+	dbRollbackCmd.Flags().String("to", "", "Rollback all migrations applied after this migration")
+	dbRollbackCmd.Flags().Int("steps", defaultMigrateSteps, "Number of migration groups to rollback")
+	dbRollbackCmd.Flags().Bool("dry-run", false, "Print the statements without executing them")
+	dbRollbackCmd.MarkFlagsMutuallyExclusive("to", "steps")
+
+	dbExportCmd.Flags().String("file", defaultBackupFile, "Path to the archive, use - for stdout")
+	dbExportCmd.Flags().Bool("uploads", defaultBackupUploads, "Include uploaded assets")
+
+	dbImportCmd.Flags().String("file", defaultBackupFile, "Path to the archive, use - for stdin")
+	dbImportCmd.Flags().Bool("uploads", defaultBackupUploads, "Include uploaded assets")
+	dbImportCmd.Flags().Bool("truncate", defaultBackupTruncate, "Delete existing records before import")
 }
 
 func dbCleanupAction(ccmd *cobra.Command, _ []string) {
@@ -123,6 +172,58 @@ func dbCleanupAction(ccmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if withUploads, _ := ccmd.Flags().GetBool("uploads"); withUploads {
+		uploads, err := setupUploads(cfg)
+
+		if err != nil {
+			log.Error().
+				Err(err).
+				Msg("Failed to setup uploads")
+
+			os.Exit(1)
+		}
+
+		defer func() { _ = uploads.Close() }()
+
+		grace := cfg.Cleanup.Grace
+
+		if ccmd.Flags().Changed("grace") {
+			grace, _ = ccmd.Flags().GetDuration("grace")
+		}
+
+		dryRun, _ := ccmd.Flags().GetBool("dry-run")
+
+		removed, err := storage.Uploads.Cleanup(
+			ccmd.Context(),
+			uploads,
+			grace,
+			dryRun,
+		)
+
+		for _, object := range removed {
+			log.Info().
+				Str("key", object.Key).
+				Int64("size", object.Size).
+				Time("modified", object.ModTime).
+				Bool("dry_run", dryRun).
+				Msg("Orphaned upload")
+		}
+
+		if err != nil {
+			log.Error().
+				Err(err).
+				Msg("Failed to cleanup orphaned uploads")
+
+			os.Exit(1)
+		}
+
+		log.Info().
+			Int("count", len(removed)).
+			Str("grace", grace.String()).
+			Bool("dry_run", dryRun).
+			Msg("Finished uploads cleanup")
+	}
+
 	log.Info().
 		Msg("Finished cleanup task")
 }
@@ -131,7 +232,16 @@ func dbMigrateAction(ccmd *cobra.Command, _ []string) {
 	storage := prepareStorage(ccmd.Context())
 	defer func() { _, _ = storage.Close() }()
 
-	group, err := storage.Migrate(ccmd.Context())
+	opts := store.MigrateOptions{}
+	opts.Target, _ = ccmd.Flags().GetString("to")
+	opts.DryRun, _ = ccmd.Flags().GetBool("dry-run")
+
+	group, statements, err := storage.Migrate(
+		ccmd.Context(),
+		opts,
+	)
+
+	printStatements(ccmd.OutOrStdout(), statements)
 
 	if err != nil {
 		log.Fatal().
@@ -144,8 +254,13 @@ func dbMigrateAction(ccmd *cobra.Command, _ []string) {
 	if group.IsZero() {
 		log.Info().
 			Msg("Noting to migrate")
+	} else if opts.DryRun {
+		log.Info().
+			Str("migrations", group.Migrations.String()).
+			Msg("Finished migrate dry-run")
 	} else {
 		log.Debug().
+			Str("migrations", group.Migrations.String()).
 			Msg("Finished migrate")
 	}
 }
@@ -154,7 +269,17 @@ func dbRollbackAction(ccmd *cobra.Command, _ []string) {
 	storage := prepareStorage(ccmd.Context())
 	defer func() { _, _ = storage.Close() }()
 
-	group, err := storage.Rollback(ccmd.Context())
+	opts := store.MigrateOptions{}
+	opts.Target, _ = ccmd.Flags().GetString("to")
+	opts.Steps, _ = ccmd.Flags().GetInt("steps")
+	opts.DryRun, _ = ccmd.Flags().GetBool("dry-run")
+
+	group, statements, err := storage.Rollback(
+		ccmd.Context(),
+		opts,
+	)
+
+	printStatements(ccmd.OutOrStdout(), statements)
 
 	if err != nil {
 		log.Fatal().
@@ -167,8 +292,13 @@ func dbRollbackAction(ccmd *cobra.Command, _ []string) {
 	if group.IsZero() {
 		log.Info().
 			Msg("Noting to rollback")
+	} else if opts.DryRun {
+		log.Info().
+			Str("migrations", group.Migrations.String()).
+			Msg("Finished rollback dry-run")
 	} else {
 		log.Debug().
+			Str("migrations", group.Migrations.String()).
 			Msg("Finished rollback")
 	}
 }
@@ -313,10 +443,170 @@ func dbCreateAction(ccmd *cobra.Command, args []string) {
 		Msg("Finished generating")
 }
 
+func dbExportAction(ccmd *cobra.Command, _ []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	file, _ := ccmd.Flags().GetString("file")
+	withUploads, _ := ccmd.Flags().GetBool("uploads")
+
+	var (
+		uploads upload.Upload
+		err     error
+	)
+
+	if withUploads {
+		uploads, err = setupUploads(cfg)
+
+		if err != nil {
+			log.Error().
+				Err(err).
+				Msg("Failed to setup uploads")
+
+			os.Exit(1)
+		}
+
+		defer func() { _ = uploads.Close() }()
+	}
+
+	var (
+		writer = os.Stdout
+	)
+
+	if file != "-" {
+		writer, err = os.OpenFile(
+			file,
+			os.O_WRONLY|os.O_CREATE|os.O_TRUNC,
+			0o600,
+		)
+
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("file", file).
+				Msg("Failed to open archive")
+
+			os.Exit(1)
+		}
+
+		defer func() { _ = writer.Close() }()
+	}
+
+	manifest, err := backup.Export(
+		ccmd.Context(),
+		storage.Handle(),
+		uploads,
+		writer,
+		backup.Options{
+			Uploads: withUploads,
+		},
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("file", file).
+			Msg("Failed to export database")
+
+		os.Exit(1)
+	}
+
+	log.Info().
+		Str("file", file).
+		Int("version", manifest.Version).
+		Interface("tables", manifest.Tables).
+		Int64("uploads", manifest.Uploads).
+		Msg("Finished export")
+}
+
+func dbImportAction(ccmd *cobra.Command, _ []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	file, _ := ccmd.Flags().GetString("file")
+	withUploads, _ := ccmd.Flags().GetBool("uploads")
+	truncate, _ := ccmd.Flags().GetBool("truncate")
+
+	if _, _, err := storage.Migrate(ccmd.Context(), store.MigrateOptions{}); err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to migrate database")
+
+		os.Exit(1)
+	}
+
+	var (
+		uploads upload.Upload
+		err     error
+	)
+
+	if withUploads {
+		uploads, err = setupUploads(cfg)
+
+		if err != nil {
+			log.Error().
+				Err(err).
+				Msg("Failed to setup uploads")
+
+			os.Exit(1)
+		}
+
+		defer func() { _ = uploads.Close() }()
+	}
+
+	var (
+		reader = os.Stdin
+	)
+
+	if file != "-" {
+		reader, err = os.Open(file)
+
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("file", file).
+				Msg("Failed to open archive")
+
+			os.Exit(1)
+		}
+
+		defer func() { _ = reader.Close() }()
+	}
+
+	manifest, err := backup.Import(
+		ccmd.Context(),
+		storage.Handle(),
+		uploads,
+		reader,
+		backup.Options{
+			Uploads:  withUploads,
+			Truncate: truncate,
+		},
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("file", file).
+			Msg("Failed to import database")
+
+		os.Exit(1)
+	}
+
+	log.Info().
+		Str("file", file).
+		Str("driver", manifest.Driver).
+		Time("created", manifest.CreatedAt).
+		Interface("tables", manifest.Tables).
+		Int64("uploads", manifest.Uploads).
+		Msg("Finished import")
+}
+
 func prepareStorage(ctx context.Context) *store.Store {
 	storage, err := store.NewStore(
 		cfg.Database,
 		cfg.Scim,
+		cfg.Quota,
 		nil,
 	)
 
@@ -370,3 +660,9 @@ func prepareStorage(ctx context.Context) *store.Store {
 
 	return storage
 }
+
+func printStatements(w io.Writer, statements []string) {
+	for _, statement := range statements {
+		_, _ = fmt.Fprintln(w, statement)
+	}
+}
@@ -0,0 +1,19 @@
+package command
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintStatements(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	printStatements(buf, []string{
+		"CREATE TABLE users",
+		"CREATE TABLE groups",
+	})
+
+	assert.Equal(t, "CREATE TABLE users\nCREATE TABLE groups\n", buf.String())
+}
@@ -0,0 +1,467 @@
+package command
+
+import (
+	"os"
+	"time"
+
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	groupCmd = &cobra.Command{
+		Use:   "group",
+		Short: "Group management",
+		Args:  cobra.NoArgs,
+	}
+
+	groupListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List all groups",
+		Run:   groupListAction,
+		Args:  cobra.NoArgs,
+	}
+
+	groupShowCmd = &cobra.Command{
+		Use:   "show <group>",
+		Short: "Show a group",
+		Run:   groupShowAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	groupCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Create a group",
+		Run:   groupCreateAction,
+		Args:  cobra.NoArgs,
+	}
+
+	groupUpdateCmd = &cobra.Command{
+		Use:   "update <group>",
+		Short: "Update a group",
+		Run:   groupUpdateAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	groupDeleteCmd = &cobra.Command{
+		Use:   "delete <group>",
+		Short: "Delete a group",
+		Run:   groupDeleteAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	groupUsersCmd = &cobra.Command{
+		Use:   "users <group>",
+		Short: "List users of a group",
+		Run:   groupUsersAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	groupAttachCmd = &cobra.Command{
+		Use:   "attach <group>",
+		Short: "Attach an user to a group",
+		Run:   groupAttachAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	groupPermitCmd = &cobra.Command{
+		Use:   "permit <group>",
+		Short: "Update user perms for a group",
+		Run:   groupPermitAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	groupDropCmd = &cobra.Command{
+		Use:   "drop <group>",
+		Short: "Drop an user from a group",
+		Run:   groupDropAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	defaultGroupPerm = model.UserGroupUserPerm
+)
+
+func init() {
+	rootCmd.AddCommand(groupCmd)
+
+	groupCmd.AddCommand(groupListCmd)
+	groupCmd.AddCommand(groupShowCmd)
+	groupCmd.AddCommand(groupCreateCmd)
+	groupCmd.AddCommand(groupUpdateCmd)
+	groupCmd.AddCommand(groupDeleteCmd)
+	groupCmd.AddCommand(groupUsersCmd)
+	groupCmd.AddCommand(groupAttachCmd)
+	groupCmd.AddCommand(groupPermitCmd)
+	groupCmd.AddCommand(groupDropCmd)
+
+	for _, cmd := range []*cobra.Command{
+		groupListCmd,
+		groupShowCmd,
+		groupCreateCmd,
+		groupUpdateCmd,
+		groupUsersCmd,
+	} {
+		cmd.Flags().StringP("output", "o", defaultOutputFormat, "Output format, table or json")
+	}
+
+	addListFlags(groupListCmd)
+	addListFlags(groupUsersCmd)
+
+	for _, cmd := range []*cobra.Command{
+		groupCreateCmd,
+		groupUpdateCmd,
+	} {
+		cmd.Flags().String("slug", "", "Slug for the group")
+		cmd.Flags().String("name", "", "Name for the group")
+	}
+
+	for _, cmd := range []*cobra.Command{
+		groupAttachCmd,
+		groupPermitCmd,
+		groupDropCmd,
+	} {
+		cmd.Flags().String("user", "", "User identifier or username")
+		_ = cmd.MarkFlagRequired("user")
+	}
+
+	groupAttachCmd.Flags().String("perm", defaultGroupPerm, "Permission for the user, user or admin")
+	groupPermitCmd.Flags().String("perm", defaultGroupPerm, "Permission for the user, user or admin")
+}
+
+func groupListAction(ccmd *cobra.Command, _ []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	records, meta, err := storage.Groups.List(
+		ccmd.Context(),
+		listParamsFromFlags(ccmd),
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to load groups")
+
+		os.Exit(1)
+	}
+
+	logListMeta(meta)
+
+	rows := make([][]string, 0, len(records))
+	payload := make([]groupOutput, 0, len(records))
+
+	for _, record := range records {
+		rows = append(rows, groupRow(record))
+		payload = append(payload, newGroupOutput(record))
+	}
+
+	output, _ := ccmd.Flags().GetString("output")
+
+	if err := renderOutput(
+		ccmd.OutOrStdout(),
+		output,
+		groupHeaders(),
+		rows,
+		payload,
+	); err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to render groups")
+
+		os.Exit(1)
+	}
+}
+
+func groupShowAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	record, err := storage.Groups.Show(
+		ccmd.Context(),
+		args[0],
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("group", args[0]).
+			Msg("Failed to load group")
+
+		os.Exit(1)
+	}
+
+	renderGroup(ccmd, record)
+}
+
+func groupCreateAction(ccmd *cobra.Command, _ []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	record := &model.Group{}
+	record.Slug, _ = ccmd.Flags().GetString("slug")
+	record.Name, _ = ccmd.Flags().GetString("name")
+
+	if err := storage.Groups.Create(
+		ccmd.Context(),
+		record,
+	); err != nil {
+		if !logValidation(err) {
+			log.Error().
+				Err(err).
+				Msg("Failed to create group")
+		}
+
+		os.Exit(1)
+	}
+
+	renderGroup(ccmd, record)
+}
+
+func groupUpdateAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	record, err := storage.Groups.ShowPrimary(
+		ccmd.Context(),
+		args[0],
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("group", args[0]).
+			Msg("Failed to load group")
+
+		os.Exit(1)
+	}
+
+	if ccmd.Flags().Changed("slug") {
+		record.Slug, _ = ccmd.Flags().GetString("slug")
+	}
+
+	if ccmd.Flags().Changed("name") {
+		record.Name, _ = ccmd.Flags().GetString("name")
+	}
+
+	if err := storage.Groups.Update(
+		ccmd.Context(),
+		record,
+	); err != nil {
+		if !logValidation(err) {
+			log.Error().
+				Err(err).
+				Str("group", args[0]).
+				Msg("Failed to update group")
+		}
+
+		os.Exit(1)
+	}
+
+	renderGroup(ccmd, record)
+}
+
+func groupDeleteAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	if err := storage.Groups.Delete(
+		ccmd.Context(),
+		args[0],
+	); err != nil {
+		log.Error().
+			Err(err).
+			Str("group", args[0]).
+			Msg("Failed to delete group")
+
+		os.Exit(1)
+	}
+
+	log.Info().
+		Str("group", args[0]).
+		Msg("Successfully deleted group")
+}
+
+func groupUsersAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	group, err := storage.Groups.Show(
+		ccmd.Context(),
+		args[0],
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("group", args[0]).
+			Msg("Failed to load group")
+
+		os.Exit(1)
+	}
+
+	records, meta, err := storage.Groups.ListUsers(
+		ccmd.Context(),
+		model.UserGroupParams{
+			ListParams: listParamsFromFlags(ccmd),
+			GroupID:    group.ID,
+		},
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("group", args[0]).
+			Msg("Failed to load group users")
+
+		os.Exit(1)
+	}
+
+	logListMeta(meta)
+
+	renderUserGroups(ccmd, records)
+}
+
+func groupAttachAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	user, _ := ccmd.Flags().GetString("user")
+	perm, _ := ccmd.Flags().GetString("perm")
+
+	if err := storage.Groups.AttachUser(
+		ccmd.Context(),
+		model.UserGroupParams{
+			GroupID: args[0],
+			UserID:  user,
+			Perm:    perm,
+		},
+	); err != nil {
+		if !logValidation(err) {
+			log.Error().
+				Err(err).
+				Str("group", args[0]).
+				Str("user", user).
+				Msg("Failed to attach user to group")
+		}
+
+		os.Exit(1)
+	}
+
+	log.Info().
+		Str("group", args[0]).
+		Str("user", user).
+		Msg("Successfully attached user to group")
+}
+
+func groupPermitAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	user, _ := ccmd.Flags().GetString("user")
+	perm, _ := ccmd.Flags().GetString("perm")
+
+	if err := storage.Groups.PermitUser(
+		ccmd.Context(),
+		model.UserGroupParams{
+			GroupID: args[0],
+			UserID:  user,
+			Perm:    perm,
+		},
+	); err != nil {
+		if !logValidation(err) {
+			log.Error().
+				Err(err).
+				Str("group", args[0]).
+				Str("user", user).
+				Msg("Failed to update group user perms")
+		}
+
+		os.Exit(1)
+	}
+
+	log.Info().
+		Str("group", args[0]).
+		Str("user", user).
+		Msg("Successfully updated group user perms")
+}
+
+func groupDropAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	user, _ := ccmd.Flags().GetString("user")
+
+	if err := storage.Groups.DropUser(
+		ccmd.Context(),
+		model.UserGroupParams{
+			GroupID: args[0],
+			UserID:  user,
+		},
+	); err != nil {
+		log.Error().
+			Err(err).
+			Str("group", args[0]).
+			Str("user", user).
+			Msg("Failed to drop user from group")
+
+		os.Exit(1)
+	}
+
+	log.Info().
+		Str("group", args[0]).
+		Str("user", user).
+		Msg("Successfully dropped user from group")
+}
+
+func renderGroup(ccmd *cobra.Command, record *model.Group) {
+	output, _ := ccmd.Flags().GetString("output")
+
+	if err := renderOutput(
+		ccmd.OutOrStdout(),
+		output,
+		groupHeaders(),
+		[][]string{groupRow(record)},
+		newGroupOutput(record),
+	); err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to render group")
+
+		os.Exit(1)
+	}
+}
+
+type groupOutput struct {
+	ID        string    `json:"id"`
+	Slug      string    `json:"slug"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newGroupOutput(record *model.Group) groupOutput {
+	return groupOutput{
+		ID:        record.ID,
+		Slug:      record.Slug,
+		Name:      record.Name,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+	}
+}
+
+func groupHeaders() []string {
+	return []string{
+		"ID",
+		"SLUG",
+		"NAME",
+	}
+}
+
+func groupRow(record *model.Group) []string {
+	return []string{
+		record.ID,
+		record.Slug,
+		record.Name,
+	}
+}
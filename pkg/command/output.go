@@ -0,0 +1,104 @@
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/gopad/gopad-api/pkg/validate"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// ErrUnknownOutput defines a named error for unknown output formats.
+	ErrUnknownOutput = fmt.Errorf("unknown output format")
+
+	defaultOutputFormat = "table"
+	defaultListLimit    = int64(100)
+	defaultListOffset   = int64(0)
+)
+
+func addListFlags(cmd *cobra.Command) {
+	cmd.Flags().String("search", "", "Search query for the list")
+	cmd.Flags().String("sort", "", "Sorting column for the list")
+	cmd.Flags().String("order", "asc", "Sorting order for the list")
+	cmd.Flags().Int64("limit", defaultListLimit, "Paging limit for the list")
+	cmd.Flags().Int64("offset", defaultListOffset, "Paging offset for the list")
+	cmd.Flags().String("cursor", "", "Paging cursor for the list, takes precedence over the offset")
+}
+
+func listParamsFromFlags(ccmd *cobra.Command) model.ListParams {
+	result := model.ListParams{}
+	result.Search, _ = ccmd.Flags().GetString("search")
+	result.Sort, _ = ccmd.Flags().GetString("sort")
+	result.Order, _ = ccmd.Flags().GetString("order")
+	result.Limit, _ = ccmd.Flags().GetInt64("limit")
+	result.Offset, _ = ccmd.Flags().GetInt64("offset")
+	result.Cursor, _ = ccmd.Flags().GetString("cursor")
+
+	return result
+}
+
+func logListMeta(meta model.ListMeta) {
+	if meta.Next == "" && meta.Prev == "" {
+		return
+	}
+
+	log.Info().
+		Str("next", meta.Next).
+		Str("prev", meta.Prev).
+		Msg("More records available")
+}
+
+func renderOutput(w io.Writer, format string, headers []string, rows [][]string, payload any) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(payload)
+	case "table":
+		writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+		if _, err := fmt.Fprintln(
+			writer,
+			strings.Join(headers, "\t"),
+		); err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			if _, err := fmt.Fprintln(
+				writer,
+				strings.Join(row, "\t"),
+			); err != nil {
+				return err
+			}
+		}
+
+		return writer.Flush()
+	}
+
+	return ErrUnknownOutput
+}
+
+func logValidation(err error) bool {
+	v, ok := err.(validate.Errors)
+
+	if !ok {
+		return false
+	}
+
+	for _, verr := range v.Errors {
+		log.Error().
+			Str("field", verr.Field).
+			Err(verr.Error).
+			Msg("Failed to validate")
+	}
+
+	return true
+}
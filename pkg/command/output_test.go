@@ -0,0 +1,77 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		expect string
+		err    error
+	}{
+		{name: "table", format: "table", expect: "ID  NAME\n1   alpha\n2   beta\n"},
+		{name: "json", format: "json", expect: "[\n  \"alpha\",\n  \"beta\"\n]\n"},
+		{name: "unknown", format: "yaml", err: ErrUnknownOutput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := renderOutput(
+				buf,
+				tt.format,
+				[]string{"ID", "NAME"},
+				[][]string{{"1", "alpha"}, {"2", "beta"}},
+				[]string{"alpha", "beta"},
+			)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, buf.String())
+		})
+	}
+}
+
+func TestRenderUser(t *testing.T) {
+	record := &model.User{
+		ID:       "abc",
+		Username: "jdoe",
+		Password: "p455w0rd",
+		Email:    "jdoe@example.com",
+		Active:   true,
+	}
+
+	for _, format := range []string{"table", "json"} {
+		t.Run(format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			cmd := &cobra.Command{}
+			cmd.Flags().StringP("output", "o", defaultOutputFormat, "")
+			cmd.SetOut(buf)
+
+			require.NoError(t, cmd.Flags().Set("output", format))
+			renderUser(cmd, record)
+
+			assert.Contains(t, buf.String(), "jdoe@example.com")
+			assert.NotContains(t, buf.String(), record.Password)
+
+			if format == "json" {
+				payload := userOutput{}
+				require.NoError(t, json.Unmarshal(buf.Bytes(), &payload))
+				assert.Equal(t, "jdoe", payload.Username)
+			}
+		})
+	}
+}
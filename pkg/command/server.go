@@ -5,15 +5,20 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 
 	"github.com/cenkalti/backoff/v7"
 	"github.com/gopad/gopad-api/pkg/authn"
+	"github.com/gopad/gopad-api/pkg/avatar"
 	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/gopad/gopad-api/pkg/mailer"
 	"github.com/gopad/gopad-api/pkg/metrics"
+	"github.com/gopad/gopad-api/pkg/model"
 	"github.com/gopad/gopad-api/pkg/router"
 	"github.com/gopad/gopad-api/pkg/secret"
 	"github.com/gopad/gopad-api/pkg/store"
+	"github.com/gopad/gopad-api/pkg/templates"
 	"github.com/oklog/run"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -46,6 +51,8 @@ var (
 	defaultDatabasePassword = ""
 	defaultDatabaseName     = "gopad.sqlite3"
 	defaultDatabaseOptions  = make(map[string]string, 0)
+	defaultDatabaseReplicas = []string{}
+	defaultDatabaseMigrate  = true
 	defaultUploadDriver     = "file"
 	defaultUploadEndpoint   = ""
 	defaultUploadPath       = ""
@@ -56,12 +63,27 @@ var (
 	defaultUploadPerms      = "0755"
 	defaultUploadPathstyle  = false
 	defaultUploadProxy      = true
+	defaultUploadKeys       = []string{}
+	defaultUploadPlaintext  = false
+	defaultAvatarGravatar   = false
+	defaultAvatarSize       = 256
+	defaultAvatarLimit      = int64(5 * 1024 * 1024)
+	defaultQuotaBytes       = int64(0)
+	defaultQuotaObjects     = int64(0)
+	defaultMailHost         = ""
+	defaultMailPort         = 25
+	defaultMailUsername     = ""
+	defaultMailPassword     = ""
+	defaultMailFrom         = "gopad@localhost"
+	defaultDigestEnabled    = false
+	defaultDigestInterval   = 24 * time.Hour
 	defaultTokenSecret      = secret.Generate(32)
 	defaultTokenExpire      = time.Hour * 1
 	defaultScimEnabled      = false
 	defaultScimToken        = ""
 	defaultCleanupEnabled   = true
 	defaultCleanupInterval  = 30 * time.Minute
+	defaultCleanupGrace     = 24 * time.Hour
 	defaultAdminCreate      = true
 	defaultAdminUsername    = "admin"
 	defaultAdminPassword    = "admin"
@@ -144,6 +166,14 @@ func init() {
 	viper.SetDefault("database.options", defaultDatabaseOptions)
 	_ = viper.BindPFlag("database.options", serverCmd.PersistentFlags().Lookup("database-options"))
 
+	serverCmd.PersistentFlags().StringSlice("database-replicas", defaultDatabaseReplicas, "DSNs for read-only database replicas")
+	viper.SetDefault("database.replicas", defaultDatabaseReplicas)
+	_ = viper.BindPFlag("database.replicas", serverCmd.PersistentFlags().Lookup("database-replicas"))
+
+	serverCmd.PersistentFlags().Bool("database-migrate", defaultDatabaseMigrate, "Execute pending migrations on startup, otherwise refuse to start")
+	viper.SetDefault("database.migrate", defaultDatabaseMigrate)
+	_ = viper.BindPFlag("database.migrate", serverCmd.PersistentFlags().Lookup("database-migrate"))
+
 	serverCmd.PersistentFlags().String("upload-driver", defaultUploadDriver, "Driver for the uploads")
 	viper.SetDefault("upload.driver", defaultUploadDriver)
 	_ = viper.BindPFlag("upload.driver", serverCmd.PersistentFlags().Lookup("upload-driver"))
@@ -184,6 +214,70 @@ func init() {
 	viper.SetDefault("upload.proxy", defaultUploadProxy)
 	_ = viper.BindPFlag("upload.proxy", serverCmd.PersistentFlags().Lookup("upload-proxy"))
 
+	serverCmd.PersistentFlags().StringSlice("upload-keys", defaultUploadKeys, "Encryption keys for uploads as id:value, first one encrypts")
+	viper.SetDefault("upload.keys", defaultUploadKeys)
+	_ = viper.BindPFlag("upload.keys", serverCmd.PersistentFlags().Lookup("upload-keys"))
+
+	serverCmd.PersistentFlags().Bool("upload-plaintext", defaultUploadPlaintext, "Serve unencrypted uploads stored before encryption got enabled")
+	viper.SetDefault("upload.plaintext", defaultUploadPlaintext)
+	_ = viper.BindPFlag("upload.plaintext", serverCmd.PersistentFlags().Lookup("upload-plaintext"))
+
+	serverCmd.PersistentFlags().Bool("avatar-gravatar", defaultAvatarGravatar, "Use Gravatar if no avatar got uploaded")
+	viper.SetDefault("avatar.gravatar", defaultAvatarGravatar)
+	_ = viper.BindPFlag("avatar.gravatar", serverCmd.PersistentFlags().Lookup("avatar-gravatar"))
+
+	serverCmd.PersistentFlags().Int("avatar-size", defaultAvatarSize, "Width and height for resized avatars")
+	viper.SetDefault("avatar.size", defaultAvatarSize)
+	_ = viper.BindPFlag("avatar.size", serverCmd.PersistentFlags().Lookup("avatar-size"))
+
+	serverCmd.PersistentFlags().Int64("avatar-limit", defaultAvatarLimit, "Maximum size in bytes for avatar uploads")
+	viper.SetDefault("avatar.limit", defaultAvatarLimit)
+	_ = viper.BindPFlag("avatar.limit", serverCmd.PersistentFlags().Lookup("avatar-limit"))
+
+	serverCmd.PersistentFlags().Int64("quota-user-bytes", defaultQuotaBytes, "Maximum stored bytes per user, 0 disables the limit")
+	viper.SetDefault("quota.user.bytes", defaultQuotaBytes)
+	_ = viper.BindPFlag("quota.user.bytes", serverCmd.PersistentFlags().Lookup("quota-user-bytes"))
+
+	serverCmd.PersistentFlags().Int64("quota-user-objects", defaultQuotaObjects, "Maximum stored objects per user, 0 disables the limit")
+	viper.SetDefault("quota.user.objects", defaultQuotaObjects)
+	_ = viper.BindPFlag("quota.user.objects", serverCmd.PersistentFlags().Lookup("quota-user-objects"))
+
+	serverCmd.PersistentFlags().Int64("quota-group-bytes", defaultQuotaBytes, "Maximum stored bytes per group, 0 disables the limit")
+	viper.SetDefault("quota.group.bytes", defaultQuotaBytes)
+	_ = viper.BindPFlag("quota.group.bytes", serverCmd.PersistentFlags().Lookup("quota-group-bytes"))
+
+	serverCmd.PersistentFlags().Int64("quota-group-objects", defaultQuotaObjects, "Maximum stored objects per group, 0 disables the limit")
+	viper.SetDefault("quota.group.objects", defaultQuotaObjects)
+	_ = viper.BindPFlag("quota.group.objects", serverCmd.PersistentFlags().Lookup("quota-group-objects"))
+
+	serverCmd.PersistentFlags().String("mail-host", defaultMailHost, "Host of the SMTP server")
+	viper.SetDefault("mail.host", defaultMailHost)
+	_ = viper.BindPFlag("mail.host", serverCmd.PersistentFlags().Lookup("mail-host"))
+
+	serverCmd.PersistentFlags().Int("mail-port", defaultMailPort, "Port of the SMTP server")
+	viper.SetDefault("mail.port", defaultMailPort)
+	_ = viper.BindPFlag("mail.port", serverCmd.PersistentFlags().Lookup("mail-port"))
+
+	serverCmd.PersistentFlags().String("mail-username", defaultMailUsername, "Username for the SMTP server")
+	viper.SetDefault("mail.username", defaultMailUsername)
+	_ = viper.BindPFlag("mail.username", serverCmd.PersistentFlags().Lookup("mail-username"))
+
+	serverCmd.PersistentFlags().String("mail-password", defaultMailPassword, "Password for the SMTP server")
+	viper.SetDefault("mail.password", defaultMailPassword)
+	_ = viper.BindPFlag("mail.password", serverCmd.PersistentFlags().Lookup("mail-password"))
+
+	serverCmd.PersistentFlags().String("mail-from", defaultMailFrom, "Sender address for mails")
+	viper.SetDefault("mail.from", defaultMailFrom)
+	_ = viper.BindPFlag("mail.from", serverCmd.PersistentFlags().Lookup("mail-from"))
+
+	serverCmd.PersistentFlags().Bool("digest-enabled", defaultDigestEnabled, "Enable notification digest mails")
+	viper.SetDefault("digest.enabled", defaultDigestEnabled)
+	_ = viper.BindPFlag("digest.enabled", serverCmd.PersistentFlags().Lookup("digest-enabled"))
+
+	serverCmd.PersistentFlags().Duration("digest-interval", defaultDigestInterval, "Interval for notification digest mails")
+	viper.SetDefault("digest.interval", defaultDigestInterval)
+	_ = viper.BindPFlag("digest.interval", serverCmd.PersistentFlags().Lookup("digest-interval"))
+
 	serverCmd.PersistentFlags().String("token-secret", defaultTokenSecret, "Token encryption secret")
 	viper.SetDefault("token.secret", defaultTokenSecret)
 	_ = viper.BindPFlag("token.secret", serverCmd.PersistentFlags().Lookup("token-secret"))
@@ -208,6 +302,14 @@ func init() {
 	viper.SetDefault("cleanup.interval", defaultCleanupInterval)
 	_ = viper.BindPFlag("cleanup.interval", serverCmd.PersistentFlags().Lookup("cleanup-interval"))
 
+	serverCmd.PersistentFlags().Bool("cleanup-uploads", defaultCleanupUploads, "Delete orphaned uploads within periodic cleanup")
+	viper.SetDefault("cleanup.uploads", defaultCleanupUploads)
+	_ = viper.BindPFlag("cleanup.uploads", serverCmd.PersistentFlags().Lookup("cleanup-uploads"))
+
+	serverCmd.PersistentFlags().Duration("cleanup-grace", defaultCleanupGrace, "Minimum age before orphaned uploads get deleted")
+	viper.SetDefault("cleanup.grace", defaultCleanupGrace)
+	_ = viper.BindPFlag("cleanup.grace", serverCmd.PersistentFlags().Lookup("cleanup-grace"))
+
 	serverCmd.PersistentFlags().Bool("admin-create", defaultAdminCreate, "Create an initial admin user")
 	viper.SetDefault("admin.create", defaultAdminCreate)
 	_ = viper.BindPFlag("admin.create", serverCmd.PersistentFlags().Lookup("admin-create"))
@@ -230,6 +332,14 @@ func init() {
 }
 
 func serverAction(ccmd *cobra.Command, _ []string) {
+	if err := avatar.Validate(cfg.Avatar.Size); err != nil {
+		log.Fatal().
+			Err(err).
+			Msg("Failed to validate avatar config")
+
+		os.Exit(1)
+	}
+
 	identity, err := authn.New(
 		authn.WithConfig(cfg.Auth.Config),
 	)
@@ -261,6 +371,7 @@ func serverAction(ccmd *cobra.Command, _ []string) {
 	storage, err := store.NewStore(
 		cfg.Database,
 		cfg.Scim,
+		cfg.Quota,
 		uploads,
 	)
 
@@ -314,10 +425,26 @@ func serverAction(ccmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
-	if _, err := storage.Migrate(ccmd.Context()); err != nil {
-		log.Fatal().
-			Err(err).
-			Msg("Failed to migrate database")
+	if cfg.Database.Migrate {
+		if _, _, err := storage.Migrate(ccmd.Context(), store.MigrateOptions{}); err != nil {
+			log.Fatal().
+				Err(err).
+				Msg("Failed to migrate database")
+		}
+	} else {
+		pending, err := storage.Pending(ccmd.Context())
+
+		if err != nil {
+			log.Fatal().
+				Err(err).
+				Msg("Failed to check migrations")
+		}
+
+		if len(pending) > 0 {
+			log.Fatal().
+				Str("pending", pending.String()).
+				Msg("Refusing to start with pending migrations")
+		}
 	}
 
 	if cfg.Admin.Create {
@@ -490,6 +617,25 @@ func serverAction(ccmd *cobra.Command, _ []string) {
 							Err(err).
 							Msg("Failed to cleanup redirect tokens")
 					}
+
+					if !cfg.Cleanup.Uploads {
+						continue
+					}
+
+					if removed, err := storage.Uploads.Cleanup(
+						context.Background(),
+						uploads,
+						cfg.Cleanup.Grace,
+						false,
+					); err != nil {
+						log.Error().
+							Err(err).
+							Msg("Failed to cleanup orphaned uploads")
+					} else if len(removed) > 0 {
+						log.Info().
+							Int("count", len(removed)).
+							Msg("Removed orphaned uploads")
+					}
 				case <-stop:
 					log.Info().
 						Msg("Shutdown periodic cleanup")
@@ -502,6 +648,61 @@ func serverAction(ccmd *cobra.Command, _ []string) {
 		})
 	}
 
+	if cfg.Digest.Enabled {
+		sender, err := mailer.New(cfg.Mail)
+
+		if err != nil {
+			log.Fatal().
+				Err(err).
+				Msg("Failed to setup mailer")
+
+			os.Exit(1)
+		}
+
+		ticker := time.NewTicker(cfg.Digest.Interval)
+		stop := make(chan struct{})
+
+		gr.Add(func() error {
+			defer ticker.Stop()
+
+			log.Info().
+				Str("interval", cfg.Digest.Interval.String()).
+				Msg("Starting notification digest")
+
+			for {
+				select {
+				case <-ticker.C:
+					log.Debug().
+						Msg("Running notification digest")
+
+					sent, err := storage.Notifications.Digest(
+						context.Background(),
+						sendDigest(sender),
+					)
+
+					if err != nil {
+						log.Error().
+							Err(err).
+							Msg("Failed to send notification digests")
+					}
+
+					if sent > 0 {
+						log.Info().
+							Int("count", sent).
+							Msg("Sent notification digests")
+					}
+				case <-stop:
+					log.Info().
+						Msg("Shutdown notification digest")
+
+					return nil
+				}
+			}
+		}, func(_ error) {
+			close(stop)
+		})
+	}
+
 	{
 		stop := make(chan os.Signal, 1)
 
@@ -520,3 +721,20 @@ func serverAction(ccmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 }
+
+func sendDigest(sender mailer.Mailer) func(context.Context, *model.User, []*model.Notification) error {
+	link := strings.TrimSuffix(cfg.Server.Host, "/") + cfg.Server.Root
+
+	return func(ctx context.Context, user *model.User, records []*model.Notification) error {
+		return sender.Send(
+			ctx,
+			user.Email,
+			"Your Gopad notifications",
+			templates.String(cfg, "digest.tmpl", map[string]any{
+				"User":          user,
+				"Notifications": records,
+				"Link":          link,
+			}),
+		)
+	}
+}
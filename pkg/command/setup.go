@@ -89,14 +89,29 @@ func readConfig() error {
 }
 
 func setupUploads(cfg *config.Config) (upload.Upload, error) {
+	var (
+		backend upload.Upload
+		err     error
+	)
+
 	switch cfg.Upload.Driver {
 	case "file":
-		return upload.NewFileUpload(cfg.Upload)
+		backend, err = upload.NewFileUpload(cfg.Upload)
 	case "s3":
-		return upload.NewS3Upload(cfg.Upload)
+		backend, err = upload.NewS3Upload(cfg.Upload)
 	case "minio":
-		return upload.NewS3Upload(cfg.Upload)
+		backend, err = upload.NewS3Upload(cfg.Upload)
+	case "azure":
+		backend, err = upload.NewAzureUpload(cfg.Upload)
+	case "gcs":
+		backend, err = upload.NewGCSUpload(cfg.Upload)
+	default:
+		return nil, upload.ErrUnknownDriver
+	}
+
+	if err != nil || len(cfg.Upload.Keys) == 0 {
+		return backend, err
 	}
 
-	return nil, upload.ErrUnknownDriver
+	return upload.NewEncryptedUpload(backend, cfg.Upload.Keys, cfg.Upload.Plaintext)
 }
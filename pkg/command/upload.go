@@ -0,0 +1,120 @@
+package command
+
+import (
+	"os"
+
+	"github.com/gopad/gopad-api/pkg/upload"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	uploadCmd = &cobra.Command{
+		Use:   "upload",
+		Short: "Upload management",
+		Args:  cobra.NoArgs,
+	}
+
+	uploadReencryptCmd = &cobra.Command{
+		Use:   "reencrypt",
+		Short: "Encrypt uploads with the current key",
+		Run:   uploadReencryptAction,
+		Args:  cobra.NoArgs,
+	}
+)
+
+func init() {
+	rootCmd.AddCommand(uploadCmd)
+
+	uploadCmd.AddCommand(uploadReencryptCmd)
+
+	uploadReencryptCmd.Flags().String("prefix", "", "Only process uploads below this prefix")
+	uploadReencryptCmd.Flags().Bool("dry-run", false, "Only report uploads which require encryption")
+}
+
+func uploadReencryptAction(ccmd *cobra.Command, _ []string) {
+	uploads, err := setupUploads(cfg)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to setup uploads")
+
+		os.Exit(1)
+	}
+
+	defer func() { _ = uploads.Close() }()
+
+	encrypted, ok := uploads.(*upload.EncryptedUpload)
+
+	if !ok {
+		log.Error().
+			Msg("Upload encryption is not configured")
+
+		os.Exit(1)
+	}
+
+	prefix, _ := ccmd.Flags().GetString("prefix")
+	dryRun, _ := ccmd.Flags().GetBool("dry-run")
+
+	objects, err := encrypted.List(
+		ccmd.Context(),
+		prefix,
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to list uploads")
+
+		os.Exit(1)
+	}
+
+	changed := 0
+	failed := 0
+
+	for _, object := range objects {
+		updated, err := encrypted.Reencrypt(
+			ccmd.Context(),
+			object.Key,
+			dryRun,
+		)
+
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("key", object.Key).
+				Msg("Failed to reencrypt upload")
+
+			failed++
+			continue
+		}
+
+		if !updated {
+			continue
+		}
+
+		if dryRun {
+			log.Info().
+				Str("key", object.Key).
+				Msg("Upload requires reencryption")
+		} else {
+			log.Info().
+				Str("key", object.Key).
+				Msg("Reencrypted upload")
+		}
+
+		changed++
+	}
+
+	log.Info().
+		Int("total", len(objects)).
+		Int("changed", changed).
+		Int("failed", failed).
+		Bool("dry_run", dryRun).
+		Msg("Finished reencryption")
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,722 @@
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/gopad/gopad-api/pkg/secret"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var (
+	userCmd = &cobra.Command{
+		Use:   "user",
+		Short: "User management",
+		Args:  cobra.NoArgs,
+	}
+
+	userListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List all users",
+		Run:   userListAction,
+		Args:  cobra.NoArgs,
+	}
+
+	userShowCmd = &cobra.Command{
+		Use:   "show <user>",
+		Short: "Show an user",
+		Run:   userShowAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	userCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Create an user",
+		Run:   userCreateAction,
+		Args:  cobra.NoArgs,
+	}
+
+	userUpdateCmd = &cobra.Command{
+		Use:   "update <user>",
+		Short: "Update an user",
+		Run:   userUpdateAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	userDeleteCmd = &cobra.Command{
+		Use:   "delete <user>",
+		Short: "Delete an user",
+		Run:   userDeleteAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	userActivateCmd = &cobra.Command{
+		Use:   "activate <user>",
+		Short: "Activate an user",
+		Run:   userActivateAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	userDeactivateCmd = &cobra.Command{
+		Use:   "deactivate <user>",
+		Short: "Deactivate an user",
+		Run:   userDeactivateAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	userPasswordCmd = &cobra.Command{
+		Use:   "password <user>",
+		Short: "Reset password for an user",
+		Run:   userPasswordAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	userGroupsCmd = &cobra.Command{
+		Use:   "groups <user>",
+		Short: "List groups of an user",
+		Run:   userGroupsAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	userAttachCmd = &cobra.Command{
+		Use:   "attach <user>",
+		Short: "Attach an user to a group",
+		Run:   userAttachAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	userPermitCmd = &cobra.Command{
+		Use:   "permit <user>",
+		Short: "Update group perms for an user",
+		Run:   userPermitAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	userDropCmd = &cobra.Command{
+		Use:   "drop <user>",
+		Short: "Drop an user from a group",
+		Run:   userDropAction,
+		Args:  cobra.ExactArgs(1),
+	}
+
+	defaultUserActive = true
+	defaultUserAdmin  = false
+	defaultUserPerm   = model.UserGroupUserPerm
+)
+
+func init() {
+	rootCmd.AddCommand(userCmd)
+
+	userCmd.AddCommand(userListCmd)
+	userCmd.AddCommand(userShowCmd)
+	userCmd.AddCommand(userCreateCmd)
+	userCmd.AddCommand(userUpdateCmd)
+	userCmd.AddCommand(userDeleteCmd)
+	userCmd.AddCommand(userActivateCmd)
+	userCmd.AddCommand(userDeactivateCmd)
+	userCmd.AddCommand(userPasswordCmd)
+	userCmd.AddCommand(userGroupsCmd)
+	userCmd.AddCommand(userAttachCmd)
+	userCmd.AddCommand(userPermitCmd)
+	userCmd.AddCommand(userDropCmd)
+
+	for _, cmd := range []*cobra.Command{
+		userListCmd,
+		userShowCmd,
+		userCreateCmd,
+		userUpdateCmd,
+		userGroupsCmd,
+	} {
+		cmd.Flags().StringP("output", "o", defaultOutputFormat, "Output format, table or json")
+	}
+
+	addListFlags(userListCmd)
+	addListFlags(userGroupsCmd)
+
+	for _, cmd := range []*cobra.Command{
+		userCreateCmd,
+		userUpdateCmd,
+	} {
+		cmd.Flags().String("username", "", "Username for the user")
+		cmd.Flags().String("password", "", "Password for the user")
+		cmd.Flags().String("email", "", "Email for the user")
+		cmd.Flags().String("fullname", "", "Fullname for the user")
+		cmd.Flags().Bool("admin", defaultUserAdmin, "Grant admin permissions")
+		cmd.Flags().Bool("active", defaultUserActive, "Mark the user as active")
+	}
+
+	userPasswordCmd.Flags().String("password", "", "New password, generated if empty")
+
+	for _, cmd := range []*cobra.Command{
+		userAttachCmd,
+		userPermitCmd,
+		userDropCmd,
+	} {
+		cmd.Flags().String("group", "", "Group identifier or slug")
+		_ = cmd.MarkFlagRequired("group")
+	}
+
+	userAttachCmd.Flags().String("perm", defaultUserPerm, "Permission for the group, user or admin")
+	userPermitCmd.Flags().String("perm", defaultUserPerm, "Permission for the group, user or admin")
+}
+
+func userListAction(ccmd *cobra.Command, _ []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	records, meta, err := storage.Users.List(
+		ccmd.Context(),
+		listParamsFromFlags(ccmd),
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to load users")
+
+		os.Exit(1)
+	}
+
+	logListMeta(meta)
+
+	rows := make([][]string, 0, len(records))
+	payload := make([]userOutput, 0, len(records))
+
+	for _, record := range records {
+		rows = append(rows, userRow(record))
+		payload = append(payload, newUserOutput(record))
+	}
+
+	output, _ := ccmd.Flags().GetString("output")
+
+	if err := renderOutput(
+		ccmd.OutOrStdout(),
+		output,
+		userHeaders(),
+		rows,
+		payload,
+	); err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to render users")
+
+		os.Exit(1)
+	}
+}
+
+func userShowAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	record, err := storage.Users.Show(
+		ccmd.Context(),
+		args[0],
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("user", args[0]).
+			Msg("Failed to load user")
+
+		os.Exit(1)
+	}
+
+	renderUser(ccmd, record)
+}
+
+func userCreateAction(ccmd *cobra.Command, _ []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	record := &model.User{}
+	record.Username, _ = ccmd.Flags().GetString("username")
+	record.Password, _ = ccmd.Flags().GetString("password")
+	record.Email, _ = ccmd.Flags().GetString("email")
+	record.Fullname, _ = ccmd.Flags().GetString("fullname")
+	record.Admin, _ = ccmd.Flags().GetBool("admin")
+	record.Active, _ = ccmd.Flags().GetBool("active")
+	generated := record.Password == ""
+
+	if generated {
+		record.Password = secret.Generate(16)
+	}
+
+	if err := storage.Users.Create(
+		ccmd.Context(),
+		record,
+	); err != nil {
+		if !logValidation(err) {
+			log.Error().
+				Err(err).
+				Msg("Failed to create user")
+		}
+
+		os.Exit(1)
+	}
+
+	renderUser(ccmd, record)
+
+	if generated {
+		printPassword(ccmd.OutOrStdout(), record.Password)
+	}
+}
+
+func userUpdateAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	record, err := storage.Users.ShowPrimary(
+		ccmd.Context(),
+		args[0],
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("user", args[0]).
+			Msg("Failed to load user")
+
+		os.Exit(1)
+	}
+
+	if ccmd.Flags().Changed("username") {
+		record.Username, _ = ccmd.Flags().GetString("username")
+	}
+
+	if ccmd.Flags().Changed("password") {
+		record.Password, _ = ccmd.Flags().GetString("password")
+	}
+
+	if ccmd.Flags().Changed("email") {
+		record.Email, _ = ccmd.Flags().GetString("email")
+	}
+
+	if ccmd.Flags().Changed("fullname") {
+		record.Fullname, _ = ccmd.Flags().GetString("fullname")
+	}
+
+	if ccmd.Flags().Changed("admin") {
+		record.Admin, _ = ccmd.Flags().GetBool("admin")
+	}
+
+	if ccmd.Flags().Changed("active") {
+		record.Active, _ = ccmd.Flags().GetBool("active")
+	}
+
+	if err := storage.Users.Update(
+		ccmd.Context(),
+		record,
+	); err != nil {
+		if !logValidation(err) {
+			log.Error().
+				Err(err).
+				Str("user", args[0]).
+				Msg("Failed to update user")
+		}
+
+		os.Exit(1)
+	}
+
+	renderUser(ccmd, record)
+}
+
+func userDeleteAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	if err := storage.Users.Delete(
+		ccmd.Context(),
+		args[0],
+	); err != nil {
+		log.Error().
+			Err(err).
+			Str("user", args[0]).
+			Msg("Failed to delete user")
+
+		os.Exit(1)
+	}
+
+	log.Info().
+		Str("user", args[0]).
+		Msg("Successfully deleted user")
+}
+
+func userActivateAction(ccmd *cobra.Command, args []string) {
+	userToggleActive(ccmd, args[0], true)
+}
+
+func userDeactivateAction(ccmd *cobra.Command, args []string) {
+	userToggleActive(ccmd, args[0], false)
+}
+
+func userToggleActive(ccmd *cobra.Command, name string, active bool) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	record, err := storage.Users.ShowPrimary(
+		ccmd.Context(),
+		name,
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("user", name).
+			Msg("Failed to load user")
+
+		os.Exit(1)
+	}
+
+	record.Active = active
+
+	if err := storage.Users.Update(
+		ccmd.Context(),
+		record,
+	); err != nil {
+		if !logValidation(err) {
+			log.Error().
+				Err(err).
+				Str("user", name).
+				Msg("Failed to update user")
+		}
+
+		os.Exit(1)
+	}
+
+	log.Info().
+		Str("user", name).
+		Bool("active", active).
+		Msg("Successfully updated user")
+}
+
+func userPasswordAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	record, err := storage.Users.ShowPrimary(
+		ccmd.Context(),
+		args[0],
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("user", args[0]).
+			Msg("Failed to load user")
+
+		os.Exit(1)
+	}
+
+	record.Password, _ = ccmd.Flags().GetString("password")
+	generated := record.Password == ""
+
+	if generated {
+		record.Password = secret.Generate(16)
+	}
+
+	if err := storage.Users.Update(
+		ccmd.Context(),
+		record,
+	); err != nil {
+		if !logValidation(err) {
+			log.Error().
+				Err(err).
+				Str("user", args[0]).
+				Msg("Failed to reset password")
+		}
+
+		os.Exit(1)
+	}
+
+	log.Info().
+		Str("user", args[0]).
+		Msg("Successfully updated password")
+
+	if generated {
+		printPassword(ccmd.OutOrStdout(), record.Password)
+	}
+}
+
+func userGroupsAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	user, err := storage.Users.Show(
+		ccmd.Context(),
+		args[0],
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("user", args[0]).
+			Msg("Failed to load user")
+
+		os.Exit(1)
+	}
+
+	records, meta, err := storage.Users.ListGroups(
+		ccmd.Context(),
+		model.UserGroupParams{
+			ListParams: listParamsFromFlags(ccmd),
+			UserID:     user.ID,
+		},
+	)
+
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("user", args[0]).
+			Msg("Failed to load user groups")
+
+		os.Exit(1)
+	}
+
+	logListMeta(meta)
+
+	renderUserGroups(ccmd, records)
+}
+
+func userAttachAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	group, _ := ccmd.Flags().GetString("group")
+	perm, _ := ccmd.Flags().GetString("perm")
+
+	if err := storage.Users.AttachGroup(
+		ccmd.Context(),
+		model.UserGroupParams{
+			UserID:  args[0],
+			GroupID: group,
+			Perm:    perm,
+		},
+	); err != nil {
+		if !logValidation(err) {
+			log.Error().
+				Err(err).
+				Str("user", args[0]).
+				Str("group", group).
+				Msg("Failed to attach user to group")
+		}
+
+		os.Exit(1)
+	}
+
+	log.Info().
+		Str("user", args[0]).
+		Str("group", group).
+		Msg("Successfully attached user to group")
+}
+
+func userPermitAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	group, _ := ccmd.Flags().GetString("group")
+	perm, _ := ccmd.Flags().GetString("perm")
+
+	if err := storage.Users.PermitGroup(
+		ccmd.Context(),
+		model.UserGroupParams{
+			UserID:  args[0],
+			GroupID: group,
+			Perm:    perm,
+		},
+	); err != nil {
+		if !logValidation(err) {
+			log.Error().
+				Err(err).
+				Str("user", args[0]).
+				Str("group", group).
+				Msg("Failed to update user group perms")
+		}
+
+		os.Exit(1)
+	}
+
+	log.Info().
+		Str("user", args[0]).
+		Str("group", group).
+		Msg("Successfully updated user group perms")
+}
+
+func userDropAction(ccmd *cobra.Command, args []string) {
+	storage := prepareStorage(ccmd.Context())
+	defer func() { _, _ = storage.Close() }()
+
+	group, _ := ccmd.Flags().GetString("group")
+
+	if err := storage.Users.DropGroup(
+		ccmd.Context(),
+		model.UserGroupParams{
+			UserID:  args[0],
+			GroupID: group,
+		},
+	); err != nil {
+		log.Error().
+			Err(err).
+			Str("user", args[0]).
+			Str("group", group).
+			Msg("Failed to drop user from group")
+
+		os.Exit(1)
+	}
+
+	log.Info().
+		Str("user", args[0]).
+		Str("group", group).
+		Msg("Successfully dropped user from group")
+}
+
+func renderUser(ccmd *cobra.Command, record *model.User) {
+	output, _ := ccmd.Flags().GetString("output")
+
+	if err := renderOutput(
+		ccmd.OutOrStdout(),
+		output,
+		userHeaders(),
+		[][]string{userRow(record)},
+		newUserOutput(record),
+	); err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to render user")
+
+		os.Exit(1)
+	}
+}
+
+func renderUserGroups(ccmd *cobra.Command, records []*model.UserGroup) {
+	rows := make([][]string, 0, len(records))
+	payload := make([]userGroupOutput, 0, len(records))
+
+	for _, record := range records {
+		rows = append(rows, userGroupRow(record))
+		payload = append(payload, newUserGroupOutput(record))
+	}
+
+	output, _ := ccmd.Flags().GetString("output")
+
+	if err := renderOutput(
+		ccmd.OutOrStdout(),
+		output,
+		userGroupHeaders(),
+		rows,
+		payload,
+	); err != nil {
+		log.Error().
+			Err(err).
+			Msg("Failed to render memberships")
+
+		os.Exit(1)
+	}
+}
+
+type userOutput struct {
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Fullname  string    `json:"fullname"`
+	Active    bool      `json:"active"`
+	Admin     bool      `json:"admin"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newUserOutput(record *model.User) userOutput {
+	return userOutput{
+		ID:        record.ID,
+		Username:  record.Username,
+		Email:     record.Email,
+		Fullname:  record.Fullname,
+		Active:    record.Active,
+		Admin:     record.Admin,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+	}
+}
+
+func userHeaders() []string {
+	return []string{
+		"ID",
+		"USERNAME",
+		"EMAIL",
+		"FULLNAME",
+		"ACTIVE",
+		"ADMIN",
+	}
+}
+
+func userRow(record *model.User) []string {
+	return []string{
+		record.ID,
+		record.Username,
+		record.Email,
+		record.Fullname,
+		strconv.FormatBool(record.Active),
+		strconv.FormatBool(record.Admin),
+	}
+}
+
+type userGroupOutput struct {
+	UserID    string    `json:"user_id"`
+	Username  string    `json:"username"`
+	GroupID   string    `json:"group_id"`
+	GroupSlug string    `json:"group_slug"`
+	Perm      string    `json:"perm"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func newUserGroupOutput(record *model.UserGroup) userGroupOutput {
+	result := userGroupOutput{
+		UserID:    record.UserID,
+		GroupID:   record.GroupID,
+		Perm:      record.Perm,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+	}
+
+	if record.User != nil {
+		result.Username = record.User.Username
+	}
+
+	if record.Group != nil {
+		result.GroupSlug = record.Group.Slug
+	}
+
+	return result
+}
+
+func userGroupHeaders() []string {
+	return []string{
+		"USER",
+		"GROUP",
+		"PERM",
+		"CREATED",
+	}
+}
+
+func userGroupRow(record *model.UserGroup) []string {
+	result := newUserGroupOutput(record)
+
+	return []string{
+		fmt.Sprintf("%s (%s)", result.Username, result.UserID),
+		fmt.Sprintf("%s (%s)", result.GroupSlug, result.GroupID),
+		result.Perm,
+		result.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// printPassword writes generated passwords to the command output only, they
+// must never end up within the structured logs.
+func printPassword(w io.Writer, password string) {
+	_, _ = fmt.Fprintf(w, "Generated password: %s\n", password)
+}
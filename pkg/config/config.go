@@ -38,6 +38,8 @@ type Logs struct {
 type Cleanup struct {
 	Enabled  bool          `mapstructure:"enabled"`
 	Interval time.Duration `mapstructure:"interval"`
+	Uploads  bool          `mapstructure:"uploads"`
+	Grace    time.Duration `mapstructure:"grace"`
 }
 
 // Auth defines the authentication configuration.
@@ -54,20 +56,58 @@ type Database struct {
 	Password string            `mapstructure:"password"`
 	Name     string            `mapstructure:"name"`
 	Options  map[string]string `mapstructure:"options"`
+	Replicas []string          `mapstructure:"replicas"`
+	Migrate  bool              `mapstructure:"migrate"`
 }
 
 // Upload defines the asset upload configuration.
 type Upload struct {
-	Driver    string `mapstructure:"driver"`
-	Endpoint  string `mapstructure:"endpoint"`
-	Pathstyle bool   `mapstructure:"pathstyle"`
-	Path      string `mapstructure:"path"`
-	Access    string `mapstructure:"access"`
-	Secret    string `mapstructure:"secret"`
-	Bucket    string `mapstructure:"bucket"`
-	Region    string `mapstructure:"region"`
-	Perms     string `mapstructure:"perms"`
-	Proxy     bool   `mapstructure:"proxy"`
+	Driver    string   `mapstructure:"driver"`
+	Endpoint  string   `mapstructure:"endpoint"`
+	Pathstyle bool     `mapstructure:"pathstyle"`
+	Path      string   `mapstructure:"path"`
+	Access    string   `mapstructure:"access"`
+	Secret    string   `mapstructure:"secret"`
+	Bucket    string   `mapstructure:"bucket"`
+	Region    string   `mapstructure:"region"`
+	Perms     string   `mapstructure:"perms"`
+	Proxy     bool     `mapstructure:"proxy"`
+	Keys      []string `mapstructure:"keys"`
+	Plaintext bool     `mapstructure:"plaintext"`
+}
+
+// Avatar defines the avatar handling configuration.
+type Avatar struct {
+	Gravatar bool  `mapstructure:"gravatar"`
+	Size     int   `mapstructure:"size"`
+	Limit    int64 `mapstructure:"limit"`
+}
+
+// QuotaLimit defines byte and object limits, zero disables a limit.
+type QuotaLimit struct {
+	Bytes   int64 `mapstructure:"bytes"`
+	Objects int64 `mapstructure:"objects"`
+}
+
+// Quota defines the storage quota configuration.
+type Quota struct {
+	User  QuotaLimit `mapstructure:"user"`
+	Group QuotaLimit `mapstructure:"group"`
+}
+
+// Mail defines the mail delivery configuration.
+type Mail struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+}
+
+// Digest defines the notification digest configuration.
+type Digest struct {
+	Enabled  bool          `mapstructure:"enabled"`
+	Interval time.Duration `mapstructure:"interval"`
 }
 
 // Token defines the token handle configuration.
@@ -99,6 +139,10 @@ type Config struct {
 	Auth     Auth     `mapstructure:"auth"`
 	Database Database `mapstructure:"database"`
 	Upload   Upload   `mapstructure:"upload"`
+	Avatar   Avatar   `mapstructure:"avatar"`
+	Quota    Quota    `mapstructure:"quota"`
+	Mail     Mail     `mapstructure:"mail"`
+	Digest   Digest   `mapstructure:"digest"`
 	Token    Token    `mapstructure:"token"`
 	Scim     Scim     `mapstructure:"scim"`
 	Admin    Admin    `mapstructure:"admin"`
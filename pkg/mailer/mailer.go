@@ -0,0 +1,146 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/gopad/gopad-api/pkg/config"
+)
+
+var (
+	// ErrMissingHost is returned when no mail server has been configured.
+	ErrMissingHost = errors.New("missing mail host")
+
+	// ErrMissingFrom is returned when no sender address has been configured.
+	ErrMissingFrom = errors.New("missing mail sender")
+)
+
+// Mailer defines the interface to deliver mails.
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// SMTP delivers mails through a SMTP server, the connection gets upgraded via
+// STARTTLS if the server supports it.
+type SMTP struct {
+	host     string
+	addr     string
+	from     string
+	username string
+	password string
+}
+
+// Send implements the Mailer interface.
+func (m *SMTP) Send(ctx context.Context, to, subject, body string) error {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", m.addr)
+
+	if err != nil {
+		return fmt.Errorf("failed to connect mail server: %w", err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.host)
+
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("failed to initialize mail client: %w", err)
+	}
+
+	defer func() { _ = client.Close() }()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{
+			ServerName: m.host,
+			MinVersion: tls.VersionTLS12,
+		}); err != nil {
+			return fmt.Errorf("failed to start tls: %w", err)
+		}
+	}
+
+	if m.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, m.host)); err != nil {
+			return fmt.Errorf("failed to authenticate: %w", err)
+		}
+	}
+
+	if err := client.Mail(m.from); err != nil {
+		return err
+	}
+
+	if err := client.Rcpt(to); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := writer.Write(message(m.from, to, subject, body, time.Now())); err != nil {
+		_ = writer.Close()
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// New initializes a SMTP mailer based on the configuration.
+func New(cfg config.Mail) (Mailer, error) {
+	if cfg.Host == "" {
+		return nil, ErrMissingHost
+	}
+
+	if cfg.From == "" {
+		return nil, ErrMissingFrom
+	}
+
+	username, err := config.Value(cfg.Username)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse username secret: %w", err)
+	}
+
+	password, err := config.Value(cfg.Password)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse password secret: %w", err)
+	}
+
+	return &SMTP{
+		host:     cfg.Host,
+		addr:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		from:     cfg.From,
+		username: username,
+		password: password,
+	}, nil
+}
+
+func message(from, to, subject, body string, date time.Time) []byte {
+	buf := &bytes.Buffer{}
+
+	fmt.Fprintf(buf, "From: %s\r\n", from)
+	fmt.Fprintf(buf, "To: %s\r\n", to)
+	fmt.Fprintf(buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(buf, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	fmt.Fprintf(buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(buf, "Content-Type: text/html; charset=UTF-8\r\n")
+	fmt.Fprintf(buf, "\r\n%s", body)
+
+	return buf.Bytes()
+}
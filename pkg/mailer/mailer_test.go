@@ -0,0 +1,129 @@
+package mailer
+
+import (
+	"context"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.Mail
+		err  error
+	}{
+		{name: "valid", cfg: config.Mail{Host: "localhost", Port: 25, From: "gopad@localhost"}},
+		{name: "missing host", cfg: config.Mail{Port: 25, From: "gopad@localhost"}, err: ErrMissingHost},
+		{name: "missing from", cfg: config.Mail{Host: "localhost", Port: 25}, err: ErrMissingFrom},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSend(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	received := make(chan []string, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+
+		if err != nil {
+			return
+		}
+
+		defer func() { _ = conn.Close() }()
+
+		text := textproto.NewConn(conn)
+		commands := make([]string, 0)
+
+		_ = text.PrintfLine("220 localhost ESMTP")
+
+		for {
+			line, err := text.ReadLine()
+
+			if err != nil {
+				return
+			}
+
+			commands = append(commands, line)
+
+			switch {
+			case strings.HasPrefix(line, "EHLO"):
+				_ = text.PrintfLine("250 localhost")
+			case line == "DATA":
+				_ = text.PrintfLine("354 go ahead")
+
+				lines, err := text.ReadDotLines()
+
+				if err != nil {
+					return
+				}
+
+				commands = append(commands, lines...)
+				_ = text.PrintfLine("250 queued")
+			case line == "QUIT":
+				_ = text.PrintfLine("221 bye")
+				received <- commands
+
+				return
+			default:
+				_ = text.PrintfLine("250 ok")
+			}
+		}
+	}()
+
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+
+	number, err := strconv.Atoi(port)
+	require.NoError(t, err)
+
+	sender, err := New(config.Mail{
+		Host: host,
+		Port: number,
+		From: "gopad@localhost",
+	})
+
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, sender.Send(ctx, "jdoe@example.com", "Notifications", "<p>Hello</p>"))
+
+	select {
+	case commands := <-received:
+		assert.Contains(t, commands, "MAIL FROM:<gopad@localhost>")
+		assert.Contains(t, commands, "RCPT TO:<jdoe@example.com>")
+		assert.Contains(t, commands, "Subject: Notifications")
+		assert.Contains(t, commands, "Content-Type: text/html; charset=UTF-8")
+		assert.Contains(t, commands, "<p>Hello</p>")
+	case <-ctx.Done():
+		t.Fatal("mail has not been received")
+	}
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		type User struct {
+			bun.BaseModel `bun:"table:users"`
+		}
+
+		_, err := db.NewAddColumn().
+			Model((*User)(nil)).
+			ColumnExpr("avatar VARCHAR(255)").
+			Exec(ctx)
+
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		type User struct {
+			bun.BaseModel `bun:"table:users"`
+		}
+
+		_, err := db.NewDropColumn().
+			Model((*User)(nil)).
+			Column("avatar").
+			Exec(ctx)
+
+		return err
+	})
+}
@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		type Upload struct {
+			bun.BaseModel `bun:"table:uploads"`
+
+			ID        string    `bun:",pk,type:varchar(20)"`
+			Path      string    `bun:",unique,type:varchar(255)"`
+			UserID    string    `bun:",nullzero,type:varchar(20)"`
+			GroupID   string    `bun:",nullzero,type:varchar(20)"`
+			Size      int64     `bun:",notnull,default:0"`
+			CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+			UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+		}
+
+		_, err := db.NewCreateTable().
+			Model((*Upload)(nil)).
+			WithForeignKeys().
+			ForeignKey(`(user_id) REFERENCES users (id) ON DELETE SET NULL`).
+			ForeignKey(`(group_id) REFERENCES groups (id) ON DELETE SET NULL`).
+			Exec(ctx)
+
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		type Upload struct {
+			bun.BaseModel `bun:"table:uploads"`
+		}
+
+		_, err := db.NewDropTable().
+			Model((*Upload)(nil)).
+			IfExists().
+			Exec(ctx)
+
+		return err
+	})
+}
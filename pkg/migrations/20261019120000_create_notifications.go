@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		type Notification struct {
+			bun.BaseModel `bun:"table:notifications"`
+
+			ID        string    `bun:",pk,type:varchar(20)"`
+			UserID    string    `bun:"type:varchar(20)"`
+			Kind      string    `bun:"type:varchar(64)"`
+			Reference string    `bun:",nullzero,type:varchar(255)"`
+			Message   string    `bun:"type:text"`
+			ReadAt    time.Time `bun:",nullzero"`
+			CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+			UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+		}
+
+		_, err := db.NewCreateTable().
+			Model((*Notification)(nil)).
+			WithForeignKeys().
+			ForeignKey(`(user_id) REFERENCES users (id) ON DELETE CASCADE`).
+			Exec(ctx)
+
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		type Notification struct {
+			bun.BaseModel `bun:"table:notifications"`
+		}
+
+		_, err := db.NewDropTable().
+			Model((*Notification)(nil)).
+			IfExists().
+			Exec(ctx)
+
+		return err
+	})
+}
@@ -0,0 +1,38 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		type Notification struct {
+			bun.BaseModel `bun:"table:notifications"`
+
+			ID     string `bun:",pk,type:varchar(20)"`
+			UserID string `bun:"type:varchar(20)"`
+		}
+
+		_, err := db.NewCreateIndex().
+			Model((*Notification)(nil)).
+			Index("notifications_user_id_idx").
+			Column("user_id").
+			Exec(ctx)
+
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		type Notification struct {
+			bun.BaseModel `bun:"table:notifications"`
+		}
+
+		_, err := db.NewDropIndex().
+			Model((*Notification)(nil)).
+			IfExists().
+			Index("notifications_user_id_idx").
+			Exec(ctx)
+
+		return err
+	})
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		type User struct {
+			bun.BaseModel `bun:"table:users"`
+		}
+
+		_, err := db.NewAddColumn().
+			Model((*User)(nil)).
+			ColumnExpr("digest BOOLEAN DEFAULT FALSE").
+			Exec(ctx)
+
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		type User struct {
+			bun.BaseModel `bun:"table:users"`
+		}
+
+		_, err := db.NewDropColumn().
+			Model((*User)(nil)).
+			Column("digest").
+			Exec(ctx)
+
+		return err
+	})
+}
@@ -0,0 +1,33 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		type Group struct {
+			bun.BaseModel `bun:"table:groups"`
+		}
+
+		_, err := db.NewAddColumn().
+			Model((*Group)(nil)).
+			ColumnExpr("avatar VARCHAR(255)").
+			Exec(ctx)
+
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		type Group struct {
+			bun.BaseModel `bun:"table:groups"`
+		}
+
+		_, err := db.NewDropColumn().
+			Model((*Group)(nil)).
+			Column("avatar").
+			Exec(ctx)
+
+		return err
+	})
+}
@@ -0,0 +1,39 @@
+package migrations
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	Migrations.MustRegister(func(ctx context.Context, db *bun.DB) error {
+		type Notification struct {
+			bun.BaseModel `bun:"table:notifications"`
+
+			MailedAt time.Time `bun:",nullzero"`
+		}
+
+		field := db.Table(reflect.TypeFor[Notification]()).FieldMap["mailed_at"]
+
+		_, err := db.NewAddColumn().
+			Model((*Notification)(nil)).
+			ColumnExpr("? ?", field.SQLName, bun.Safe(field.CreateTableSQLType)).
+			Exec(ctx)
+
+		return err
+	}, func(ctx context.Context, db *bun.DB) error {
+		type Notification struct {
+			bun.BaseModel `bun:"table:notifications"`
+		}
+
+		_, err := db.NewDropColumn().
+			Model((*Notification)(nil)).
+			Column("mailed_at").
+			Exec(ctx)
+
+		return err
+	})
+}
@@ -21,6 +21,7 @@ type Group struct {
 	Scim      string       `bun:"type:varchar(255)"`
 	Slug      string       `bun:",unique,type:varchar(255)"`
 	Name      string       `bun:"type:varchar(255)"`
+	Avatar    string       `bun:",nullzero,type:varchar(255)"`
 	CreatedAt time.Time    `bun:",nullzero,notnull,default:current_timestamp"`
 	UpdatedAt time.Time    `bun:",nullzero,notnull,default:current_timestamp"`
 	Users     []*UserGroup `bun:"rel:has-many,join:id=group_id"`
@@ -0,0 +1,56 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dchest/uniuri"
+	"github.com/uptrace/bun"
+)
+
+var (
+	_ bun.BeforeAppendModelHook = (*Notification)(nil)
+)
+
+const (
+	// NotificationKindGroupAttached defines the kind for group memberships.
+	NotificationKindGroupAttached = "group_attached"
+)
+
+// Notification defines the model for notifications table.
+type Notification struct {
+	bun.BaseModel `bun:"table:notifications"`
+
+	ID        string    `bun:",pk,type:varchar(20)"`
+	UserID    string    `bun:"type:varchar(20)"`
+	User      *User     `bun:"rel:belongs-to,join:user_id=id"`
+	Kind      string    `bun:"type:varchar(64)"`
+	Reference string    `bun:",nullzero,type:varchar(255)"`
+	Message   string    `bun:"type:text"`
+	ReadAt    time.Time `bun:",nullzero"`
+	MailedAt  time.Time `bun:",nullzero"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// BeforeAppendModel implements the bun hook interface.
+func (m *Notification) BeforeAppendModel(_ context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if m.ID == "" {
+			m.ID = strings.ToLower(uniuri.NewLen(uniuri.UUIDLen))
+		}
+
+		m.CreatedAt = time.Now()
+		m.UpdatedAt = time.Now()
+	case *bun.UpdateQuery:
+		if m.ID == "" {
+			m.ID = strings.ToLower(uniuri.NewLen(uniuri.UUIDLen))
+		}
+
+		m.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
@@ -7,6 +7,23 @@ type ListParams struct {
 	Order  string
 	Limit  int64
 	Offset int64
+	Cursor string
+	Total  bool
+}
+
+// ListMeta defines the metadata for list results.
+type ListMeta struct {
+	Total int64
+	Next  string
+	Prev  string
+}
+
+// NotificationParams defines parameters for notifications.
+type NotificationParams struct {
+	ListParams
+
+	UserID string
+	Unread bool
 }
 
 // UserGroupParams defines parameters for user groups.
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/dchest/uniuri"
+	"github.com/uptrace/bun"
+)
+
+var (
+	_ bun.BeforeAppendModelHook = (*Upload)(nil)
+)
+
+// Upload defines the model for uploads table.
+type Upload struct {
+	bun.BaseModel `bun:"table:uploads"`
+
+	ID        string    `bun:",pk,type:varchar(20)"`
+	Path      string    `bun:",unique,type:varchar(255)"`
+	UserID    string    `bun:",nullzero,type:varchar(20)"`
+	User      *User     `bun:"rel:belongs-to,join:user_id=id"`
+	GroupID   string    `bun:",nullzero,type:varchar(20)"`
+	Group     *Group    `bun:"rel:belongs-to,join:group_id=id"`
+	Size      int64     `bun:",notnull,default:0"`
+	CreatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	UpdatedAt time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+// BeforeAppendModel implements the bun hook interface.
+func (m *Upload) BeforeAppendModel(_ context.Context, query bun.Query) error {
+	switch query.(type) {
+	case *bun.InsertQuery:
+		if m.ID == "" {
+			m.ID = strings.ToLower(uniuri.NewLen(uniuri.UUIDLen))
+		}
+
+		m.CreatedAt = time.Now()
+		m.UpdatedAt = time.Now()
+	case *bun.UpdateQuery:
+		if m.ID == "" {
+			m.ID = strings.ToLower(uniuri.NewLen(uniuri.UUIDLen))
+		}
+
+		m.UpdatedAt = time.Now()
+	}
+
+	return nil
+}
+
+// Usage defines the aggregated storage usage of an user or group.
+type Usage struct {
+	ID      string
+	Name    string
+	Bytes   int64
+	Objects int64
+}
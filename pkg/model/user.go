@@ -26,8 +26,10 @@ type User struct {
 	Email     string       `bun:"type:varchar(255)"`
 	Fullname  string       `bun:"type:varchar(255)"`
 	Profile   string       `bun:"-"`
+	Avatar    string       `bun:",nullzero,type:varchar(255)"`
 	Active    bool         `bun:"default:false"`
 	Admin     bool         `bun:"default:false"`
+	Digest    bool         `bun:"default:false"`
 	CreatedAt time.Time    `bun:",nullzero,notnull,default:current_timestamp"`
 	UpdatedAt time.Time    `bun:",nullzero,notnull,default:current_timestamp"`
 	Auths     []*UserAuth  `bun:"rel:has-many,join:id=user_id"`
@@ -195,6 +195,12 @@ func Server(
 					r.Get("/self", wrapper.ShowProfile)
 					r.Put("/self", wrapper.UpdateProfile)
 					r.Get("/token", wrapper.TokenProfile)
+					r.Post("/avatar", wrapper.CreateProfileAvatar)
+					r.Delete("/avatar", wrapper.DeleteProfileAvatar)
+					r.Get("/notifications", wrapper.ListProfileNotifications)
+					r.Get("/notifications/unread", wrapper.UnreadProfileNotifications)
+					r.Post("/notifications/read", wrapper.ReadAllProfileNotifications)
+					r.Post("/notifications/{notification_id}/read", wrapper.ReadProfileNotification)
 				})
 
 				r.Route("/groups", func(r chi.Router) {
@@ -208,6 +214,8 @@ func Server(
 						r.Get("/", wrapper.ShowGroup)
 						r.Delete("/", wrapper.DeleteGroup)
 						r.Put("/", wrapper.UpdateGroup)
+						r.Post("/avatar", wrapper.CreateGroupAvatar)
+						r.Delete("/avatar", wrapper.DeleteGroupAvatar)
 
 						r.Route("/users", func(r chi.Router) {
 							r.Get("/", wrapper.ListGroupUsers)
@@ -229,6 +237,8 @@ func Server(
 						r.Get("/", wrapper.ShowUser)
 						r.Delete("/", wrapper.DeleteUser)
 						r.Put("/", wrapper.UpdateUser)
+						r.Post("/avatar", wrapper.CreateUserAvatar)
+						r.Delete("/avatar", wrapper.DeleteUserAvatar)
 
 						r.Route("/groups", func(r chi.Router) {
 							r.Get("/", wrapper.ListUserGroups)
@@ -238,8 +248,12 @@ func Server(
 						})
 					})
 				})
+
+				r.With(apiv1.AllowAdminAccessOnly).Get("/usage", wrapper.ShowUsage)
 			})
 
+			r.Get("/identicons/{identicon}", apiv1.Identicon)
+
 			r.Handle("/storage/*", uploads.Handler(
 				path.Join(
 					cfg.Server.Root,
@@ -0,0 +1,231 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// cursor defines the content of an opaque keyset pagination cursor.
+type cursor struct {
+	Sort  string          `json:"s"`
+	Order string          `json:"o"`
+	Value json.RawMessage `json:"v"`
+	ID    string          `json:"i"`
+	Prev  bool            `json:"p,omitempty"`
+}
+
+// paginate applies sorting, counting and either keyset or offset pagination
+// to the query. The sort and id columns are qualified with the table alias of
+// owner, keyed resolves the owner struct for every scanned record.
+func paginate[T any](
+	ctx context.Context,
+	q *bun.SelectQuery,
+	records *[]T,
+	params model.ListParams,
+	sort, id string,
+	owner any,
+	keyed func(T) any,
+) (model.ListMeta, error) {
+	meta := model.ListMeta{}
+	order := sortOrder(params.Order)
+	table := q.DB().Table(reflect.TypeOf(owner).Elem())
+
+	if params.Total {
+		counter, err := q.Count(ctx)
+
+		if err != nil {
+			return meta, err
+		}
+
+		meta.Total = int64(counter)
+	}
+
+	current := &cursor{}
+
+	if params.Cursor != "" {
+		decoded, err := decodeCursor(params.Cursor)
+
+		if err != nil {
+			return meta, err
+		}
+
+		if decoded.Sort != sort || decoded.Order != order {
+			return meta, fmt.Errorf("%w: sorting has changed", ErrInvalidCursor)
+		}
+
+		field, ok := table.FieldMap[columnName(sort)]
+
+		if !ok {
+			return meta, fmt.Errorf("%w: unknown column", ErrInvalidCursor)
+		}
+
+		value := reflect.New(field.StructField.Type)
+
+		if err := json.Unmarshal(decoded.Value, value.Interface()); err != nil {
+			return meta, fmt.Errorf("%w: %s", ErrInvalidCursor, err)
+		}
+
+		operator := ">"
+
+		if (order == "DESC") != decoded.Prev {
+			operator = "<"
+		}
+
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where(
+				fmt.Sprintf("? %s ?", operator),
+				bun.Ident(sort),
+				value.Elem().Interface(),
+			).WhereOr(
+				fmt.Sprintf("? = ? AND ? %s ?", operator),
+				bun.Ident(sort),
+				value.Elem().Interface(),
+				bun.Ident(id),
+				decoded.ID,
+			)
+		})
+
+		current = decoded
+	}
+
+	direction := order
+
+	if current.Prev {
+		direction = flipOrder(order)
+	}
+
+	q = q.Order(
+		strings.Join([]string{sort, direction}, " "),
+		strings.Join([]string{id, direction}, " "),
+	)
+
+	if params.Limit > 0 {
+		q = q.Limit(int(params.Limit) + 1)
+	}
+
+	if params.Cursor == "" && params.Offset > 0 {
+		q = q.Offset(int(params.Offset))
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return meta, err
+	}
+
+	if params.Limit <= 0 {
+		return meta, nil
+	}
+
+	more := len(*records) > int(params.Limit)
+
+	if more {
+		*records = (*records)[:params.Limit]
+	}
+
+	if current.Prev {
+		slices.Reverse(*records)
+	}
+
+	if len(*records) == 0 {
+		return meta, nil
+	}
+
+	first := reflect.ValueOf(keyed((*records)[0])).Elem()
+	last := reflect.ValueOf(keyed((*records)[len(*records)-1])).Elem()
+
+	if more || current.Prev {
+		next, err := encodeCursor(table, sort, id, order, last, false)
+
+		if err != nil {
+			return meta, err
+		}
+
+		meta.Next = next
+	}
+
+	if (current.Prev && more) || (!current.Prev && (params.Cursor != "" || params.Offset > 0)) {
+		prev, err := encodeCursor(table, sort, id, order, first, true)
+
+		if err != nil {
+			return meta, err
+		}
+
+		meta.Prev = prev
+	}
+
+	return meta, nil
+}
+
+func encodeCursor(table *schema.Table, sort, id, order string, strct reflect.Value, prev bool) (string, error) {
+	sortField, ok := table.FieldMap[columnName(sort)]
+
+	if !ok {
+		return "", fmt.Errorf("%w: unknown column", ErrInvalidCursor)
+	}
+
+	idField, ok := table.FieldMap[columnName(id)]
+
+	if !ok {
+		return "", fmt.Errorf("%w: unknown column", ErrInvalidCursor)
+	}
+
+	value, err := json.Marshal(sortField.Value(strct).Interface())
+
+	if err != nil {
+		return "", err
+	}
+
+	content, err := json.Marshal(cursor{
+		Sort:  sort,
+		Order: order,
+		Value: value,
+		ID:    fmt.Sprint(idField.Value(strct).Interface()),
+		Prev:  prev,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(content), nil
+}
+
+func decodeCursor(val string) (*cursor, error) {
+	content, err := base64.RawURLEncoding.DecodeString(val)
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCursor, err)
+	}
+
+	result := &cursor{}
+
+	if err := json.Unmarshal(content, result); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCursor, err)
+	}
+
+	return result, nil
+}
+
+func columnName(val string) string {
+	if idx := strings.LastIndex(val, "."); idx >= 0 {
+		return val[idx+1:]
+	}
+
+	return val
+}
+
+func flipOrder(val string) string {
+	if val == "ASC" {
+		return "DESC"
+	}
+
+	return "ASC"
+}
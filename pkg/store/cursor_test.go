@@ -0,0 +1,157 @@
+package store
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{name: "valid", value: base64.RawURLEncoding.EncodeToString([]byte(`{"s":"group.name","o":"ASC","v":"beta","i":"abc"}`)), valid: true},
+		{name: "invalid base64", value: "!!!", valid: false},
+		{name: "invalid json", value: base64.RawURLEncoding.EncodeToString([]byte(`{"s":`)), valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := decodeCursor(tt.value)
+
+			if tt.valid {
+				require.NoError(t, err)
+				assert.Equal(t, "group.name", result.Sort)
+				assert.Equal(t, "ASC", result.Order)
+				assert.Equal(t, "abc", result.ID)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidCursor)
+			}
+		})
+	}
+}
+
+func TestPaginateCursor(t *testing.T) {
+	s := testStore(t, config.Quota{}, nil)
+	ctx := context.Background()
+
+	for i, name := range []string{"alpha", "beta", "beta", "beta", "gamma"} {
+		_, err := s.Handle().NewInsert().
+			Model(&model.Group{
+				Slug: fmt.Sprintf("group-%d", i),
+				Name: name,
+			}).
+			Exec(ctx)
+
+		require.NoError(t, err)
+	}
+
+	for _, order := range []string{"asc", "desc"} {
+		for _, limit := range []int64{1, 2, 3, 10} {
+			t.Run(fmt.Sprintf("%s by %d", order, limit), func(t *testing.T) {
+				all, _, err := s.Groups.List(ctx, model.ListParams{
+					Sort:  "name",
+					Order: order,
+				})
+
+				require.NoError(t, err)
+				require.Len(t, all, 5)
+
+				forward := make([]string, 0)
+				pages := make([]model.ListMeta, 0)
+				cursor := ""
+				last := 0
+
+				for {
+					records, meta, err := s.Groups.List(ctx, model.ListParams{
+						Sort:   "name",
+						Order:  order,
+						Limit:  limit,
+						Cursor: cursor,
+					})
+
+					require.NoError(t, err)
+					assert.LessOrEqual(t, int64(len(records)), limit)
+
+					for _, record := range records {
+						forward = append(forward, record.ID)
+					}
+
+					pages = append(pages, meta)
+					last = len(records)
+
+					if meta.Next == "" {
+						break
+					}
+
+					cursor = meta.Next
+				}
+
+				assert.Equal(t, groupIDs(all), forward)
+				assert.Empty(t, pages[0].Prev)
+
+				backward := make([]string, 0)
+				cursor = pages[len(pages)-1].Prev
+
+				for cursor != "" {
+					records, meta, err := s.Groups.List(ctx, model.ListParams{
+						Sort:   "name",
+						Order:  order,
+						Limit:  limit,
+						Cursor: cursor,
+					})
+
+					require.NoError(t, err)
+					require.NotEmpty(t, meta.Next)
+
+					backward = append(groupIDs(records), backward...)
+					cursor = meta.Prev
+				}
+
+				assert.Equal(t, forward[:len(forward)-last], backward)
+			})
+		}
+	}
+}
+
+func TestPaginateChangedSort(t *testing.T) {
+	s := testStore(t, config.Quota{}, nil)
+	ctx := context.Background()
+
+	testGroup(t, s, "alpha")
+	testGroup(t, s, "beta")
+
+	_, meta, err := s.Groups.List(ctx, model.ListParams{
+		Sort:  "name",
+		Limit: 1,
+	})
+
+	require.NoError(t, err)
+	require.NotEmpty(t, meta.Next)
+
+	_, _, err = s.Groups.List(ctx, model.ListParams{
+		Sort:   "slug",
+		Limit:  1,
+		Cursor: meta.Next,
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func groupIDs(records []*model.Group) []string {
+	result := make([]string, 0, len(records))
+
+	for _, record := range records {
+		result = append(result, record.ID)
+	}
+
+	return result
+}
@@ -0,0 +1,180 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/migrate"
+)
+
+var (
+	errDryRunUnsupported = errors.New("unsupported within dry-run")
+)
+
+// dryRunRecorder records all executed statements instead of running them,
+// read queries are still forwarded to the real database.
+type dryRunRecorder struct {
+	db         *sql.DB
+	mutex      sync.Mutex
+	statements []string
+}
+
+func newDryRunRecorder(db *sql.DB) *dryRunRecorder {
+	return &dryRunRecorder{
+		db:         db,
+		statements: make([]string, 0),
+	}
+}
+
+func (r *dryRunRecorder) bun(handle *bun.DB) *bun.DB {
+	return bun.NewDB(
+		sql.OpenDB(r),
+		handle.Dialect(),
+	)
+}
+
+func (r *dryRunRecorder) comment(direction string, migration *migrate.Migration) {
+	if r == nil {
+		return
+	}
+
+	r.record(fmt.Sprintf("-- %s: %s", direction, migration.String()))
+}
+
+func (r *dryRunRecorder) record(statement string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.statements = append(r.statements, statement)
+}
+
+func (r *dryRunRecorder) list() []string {
+	if r == nil {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]string{}, r.statements...)
+}
+
+// Connect implements the driver.Connector interface.
+func (r *dryRunRecorder) Connect(_ context.Context) (driver.Conn, error) {
+	return &dryRunConn{recorder: r}, nil
+}
+
+// Driver implements the driver.Connector interface.
+func (r *dryRunRecorder) Driver() driver.Driver {
+	return r
+}
+
+// Open implements the driver.Driver interface.
+func (r *dryRunRecorder) Open(_ string) (driver.Conn, error) {
+	return &dryRunConn{recorder: r}, nil
+}
+
+type dryRunConn struct {
+	recorder *dryRunRecorder
+}
+
+// Prepare implements the driver.Conn interface.
+func (c *dryRunConn) Prepare(_ string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepared statements are %w", errDryRunUnsupported)
+}
+
+// Close implements the driver.Conn interface.
+func (c *dryRunConn) Close() error {
+	return nil
+}
+
+// Begin implements the driver.Conn interface.
+func (c *dryRunConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+// Commit implements the driver.Tx interface.
+func (c *dryRunConn) Commit() error {
+	return nil
+}
+
+// Rollback implements the driver.Tx interface.
+func (c *dryRunConn) Rollback() error {
+	return nil
+}
+
+// ExecContext implements the driver.ExecerContext interface.
+func (c *dryRunConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if len(args) > 0 {
+		return nil, fmt.Errorf("query arguments are %w", errDryRunUnsupported)
+	}
+
+	c.recorder.record(query + ";")
+	return driver.RowsAffected(0), nil
+}
+
+// QueryContext implements the driver.QueryerContext interface.
+func (c *dryRunConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	values := make([]any, 0, len(args))
+
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+
+	rows, err := c.recorder.db.QueryContext(ctx, query, values...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &dryRunRows{rows: rows}, nil
+}
+
+type dryRunRows struct {
+	rows *sql.Rows
+}
+
+// Columns implements the driver.Rows interface.
+func (r *dryRunRows) Columns() []string {
+	columns, _ := r.rows.Columns()
+	return columns
+}
+
+// Close implements the driver.Rows interface.
+func (r *dryRunRows) Close() error {
+	return r.rows.Close()
+}
+
+// Next implements the driver.Rows interface.
+func (r *dryRunRows) Next(dest []driver.Value) error {
+	if !r.rows.Next() {
+		if err := r.rows.Err(); err != nil {
+			return err
+		}
+
+		return io.EOF
+	}
+
+	values := make([]any, len(dest))
+	pointers := make([]any, len(dest))
+
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	if err := r.rows.Scan(pointers...); err != nil {
+		return err
+	}
+
+	for i, value := range values {
+		dest[i] = value
+	}
+
+	return nil
+}
@@ -20,6 +20,21 @@ var (
 	// ErrUserNotFound is returned when a user was not found.
 	ErrUserNotFound = errors.New("user not found")
 
+	// ErrNotificationNotFound is returned when a notification was not found.
+	ErrNotificationNotFound = errors.New("notification not found")
+
 	// ErrTokenNotFound is returned when a token was not found.
 	ErrTokenNotFound = errors.New("token not found")
+
+	// ErrMigrationNotFound is returned when a migration target was not found.
+	ErrMigrationNotFound = errors.New("migration not found")
+
+	// ErrInvalidCursor is returned when a pagination cursor can't be used.
+	ErrInvalidCursor = errors.New("invalid cursor")
+
+	// ErrUploadUnavailable is returned when no upload backend is configured.
+	ErrUploadUnavailable = errors.New("upload backend is not available")
+
+	// ErrQuotaExceeded is returned when an upload exceeds the storage quota.
+	ErrQuotaExceeded = errors.New("storage quota exceeded")
 )
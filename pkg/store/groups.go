@@ -1,10 +1,12 @@
 package store
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/Machiel/slugify"
@@ -21,52 +23,50 @@ type Groups struct {
 }
 
 // List implements the listing of all users.
-func (s *Groups) List(ctx context.Context, params model.ListParams) ([]*model.Group, int64, error) {
+func (s *Groups) List(ctx context.Context, params model.ListParams) ([]*model.Group, model.ListMeta, error) {
 	records := make([]*model.Group, 0)
 
-	q := s.client.handle.NewSelect().
+	q := s.client.reader().NewSelect().
 		Model(&records)
 
-	if val, ok := s.ValidSort(params.Sort); ok {
-		q = q.Order(strings.Join(
-			[]string{
-				val,
-				sortOrder(params.Order),
-			},
-			" ",
-		))
-	}
-
 	if params.Search != "" {
 		q = s.client.SearchQuery(q, params.Search)
 	}
 
-	counter, err := q.Count(ctx)
+	sort, _ := s.ValidSort(params.Sort)
+	meta, err := paginate(
+		ctx,
+		q,
+		&records,
+		params,
+		sort,
+		"group.id",
+		(*model.Group)(nil),
+		func(r *model.Group) any { return r },
+	)
 
 	if err != nil {
-		return nil, 0, err
-	}
-
-	if params.Limit > 0 {
-		q = q.Limit(int(params.Limit))
+		return nil, meta, err
 	}
 
-	if params.Offset > 0 {
-		q = q.Offset(int(params.Offset))
-	}
-
-	if err := q.Scan(ctx); err != nil {
-		return nil, int64(counter), err
-	}
-
-	return records, int64(counter), nil
+	return records, meta, nil
 }
 
 // Show implements the details for a specific user.
 func (s *Groups) Show(ctx context.Context, name string) (*model.Group, error) {
+	return s.show(ctx, s.client.reader(), name)
+}
+
+// ShowPrimary implements the details for a specific group from the primary,
+// records which get written back must never be loaded from a replica.
+func (s *Groups) ShowPrimary(ctx context.Context, name string) (*model.Group, error) {
+	return s.show(ctx, s.client.handle, name)
+}
+
+func (s *Groups) show(ctx context.Context, db *bun.DB, name string) (*model.Group, error) {
 	record := &model.Group{}
 
-	if err := s.client.handle.NewSelect().
+	if err := db.NewSelect().
 		Model(record).
 		Where("id = ? OR slug = ?", name, name).
 		Scan(ctx); err != nil {
@@ -102,6 +102,10 @@ func (s *Groups) Create(ctx context.Context, record *model.Group) error {
 			return err
 		}
 
+		if s.client.principal == nil {
+			return nil
+		}
+
 		if _, err := tx.NewInsert().
 			Model(&model.UserGroup{
 				GroupID: record.ID,
@@ -143,7 +147,7 @@ func (s *Groups) Update(ctx context.Context, record *model.Group) error {
 
 // Delete implements the deletion of a group.
 func (s *Groups) Delete(ctx context.Context, name string) error {
-	record, err := s.Show(ctx, name)
+	record, err := s.show(ctx, s.client.handle, name)
 
 	if err != nil {
 		return err
@@ -156,63 +160,128 @@ func (s *Groups) Delete(ctx context.Context, name string) error {
 		return err
 	}
 
+	if record.Avatar != "" && s.client.upload != nil {
+		return s.client.upload.Delete(
+			ctx,
+			path.Join("avatars", "groups", record.ID),
+			true,
+		)
+	}
+
+	return nil
+}
+
+// SaveAvatar stores a processed avatar and replaces the previous one.
+func (s *Groups) SaveAvatar(ctx context.Context, record *model.Group, content *bytes.Buffer) error {
+	if s.client.upload == nil {
+		return ErrUploadUnavailable
+	}
+
+	previous := record.Avatar
+	key := path.Join(
+		"avatars",
+		"groups",
+		record.ID,
+		strings.ToLower(uniuri.NewLen(8))+".png",
+	)
+
+	if err := s.client.upload.Upload(
+		withUploadOwner(ctx, uploadOwner{GroupID: record.ID, Replaces: previous}),
+		key,
+		content,
+		int64(content.Len()),
+		"image/png",
+	); err != nil {
+		return err
+	}
+
+	record.Avatar = key
+
+	if _, err := s.client.handle.NewUpdate().
+		Model(record).
+		Column("avatar", "updated_at").
+		Where("id = ?", record.ID).
+		Exec(ctx); err != nil {
+		record.Avatar = previous
+		_ = s.client.upload.Delete(ctx, key, false)
+
+		return err
+	}
+
+	if previous != "" {
+		_ = s.client.upload.Delete(ctx, previous, false)
+	}
+
 	return nil
 }
 
+// DeleteAvatar removes the uploaded avatar of a group.
+func (s *Groups) DeleteAvatar(ctx context.Context, record *model.Group) error {
+	if record.Avatar == "" {
+		return nil
+	}
+
+	if s.client.upload == nil {
+		return ErrUploadUnavailable
+	}
+
+	previous := record.Avatar
+	record.Avatar = ""
+
+	if _, err := s.client.handle.NewUpdate().
+		Model(record).
+		Column("avatar", "updated_at").
+		Where("id = ?", record.ID).
+		Exec(ctx); err != nil {
+		record.Avatar = previous
+		return err
+	}
+
+	return s.client.upload.Delete(ctx, previous, false)
+}
+
 // ListUsers implements the listing of all users for a group.
-func (s *Groups) ListUsers(ctx context.Context, params model.UserGroupParams) ([]*model.UserGroup, int64, error) {
+func (s *Groups) ListUsers(ctx context.Context, params model.UserGroupParams) ([]*model.UserGroup, model.ListMeta, error) {
 	records := make([]*model.UserGroup, 0)
 
-	q := s.client.handle.NewSelect().
+	q := s.client.reader().NewSelect().
 		Model(&records).
 		Relation("User").
 		Relation("Group").
 		Where("group_id = ?", params.GroupID)
 
-	if val, ok := s.client.Users.ValidSort(params.Sort); ok {
-		q = q.Order(strings.Join(
-			[]string{
-				val,
-				sortOrder(params.Order),
-			},
-			" ",
-		))
-	}
-
 	if params.Search != "" {
 		q = s.client.SearchQuery(q, params.Search)
 	}
 
-	counter, err := q.Count(ctx)
+	sort, _ := s.client.Users.ValidSort(params.Sort)
+	meta, err := paginate(
+		ctx,
+		q,
+		&records,
+		params.ListParams,
+		sort,
+		"user.id",
+		(*model.User)(nil),
+		func(r *model.UserGroup) any { return r.User },
+	)
 
 	if err != nil {
-		return nil, 0, err
+		return nil, meta, err
 	}
 
-	if params.Limit > 0 {
-		q = q.Limit(int(params.Limit))
-	}
-
-	if params.Offset > 0 {
-		q = q.Offset(int(params.Offset))
-	}
-
-	if err := q.Scan(ctx); err != nil {
-		return nil, int64(counter), err
-	}
-
-	return records, int64(counter), nil
+	return records, meta, nil
 }
 
 // AttachUser implements the attachment of a group to an user.
 func (s *Groups) AttachUser(ctx context.Context, params model.UserGroupParams) error {
-	group, err := s.Show(ctx, params.GroupID)
+	group, err := s.show(ctx, s.client.handle, params.GroupID)
 
 	if err != nil {
 		return err
 	}
 
-	user, err := s.client.Users.Show(ctx, params.UserID)
+	user, err := s.client.Users.show(ctx, s.client.handle, params.UserID)
 
 	if err != nil {
 		return err
@@ -238,24 +307,26 @@ func (s *Groups) AttachUser(ctx context.Context, params model.UserGroupParams) e
 		return err
 	}
 
-	if _, err := s.client.handle.NewInsert().
-		Model(record).
-		Exec(ctx); err != nil {
-		return err
-	}
+	return s.client.handle.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().
+			Model(record).
+			Exec(ctx); err != nil {
+			return err
+		}
 
-	return nil
+		return s.client.Notifications.groupAttached(ctx, tx, user, group, record.Perm)
+	})
 }
 
 // PermitUser implements the permission update for a user on a group.
 func (s *Groups) PermitUser(ctx context.Context, params model.UserGroupParams) error {
-	group, err := s.Show(ctx, params.GroupID)
+	group, err := s.show(ctx, s.client.handle, params.GroupID)
 
 	if err != nil {
 		return err
 	}
 
-	user, err := s.client.Users.Show(ctx, params.UserID)
+	user, err := s.client.Users.show(ctx, s.client.handle, params.UserID)
 
 	if err != nil {
 		return err
@@ -271,6 +342,10 @@ func (s *Groups) PermitUser(ctx context.Context, params model.UserGroupParams) e
 		return ErrNotAssigned
 	}
 
+	if err := s.validatePerm(params.Perm); err != nil {
+		return err
+	}
+
 	if _, err := s.client.handle.NewUpdate().
 		Model((*model.UserGroup)(nil)).
 		Set("perm = ?", params.Perm).
@@ -284,13 +359,13 @@ func (s *Groups) PermitUser(ctx context.Context, params model.UserGroupParams) e
 
 // DropUser implements the removal of a group from an user.
 func (s *Groups) DropUser(ctx context.Context, params model.UserGroupParams) error {
-	group, err := s.Show(ctx, params.GroupID)
+	group, err := s.show(ctx, s.client.handle, params.GroupID)
 
 	if err != nil {
 		return err
 	}
 
-	user, err := s.client.Users.Show(ctx, params.UserID)
+	user, err := s.client.Users.show(ctx, s.client.handle, params.UserID)
 
 	if err != nil {
 		return err
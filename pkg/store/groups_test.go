@@ -0,0 +1,54 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupAvatar(t *testing.T) {
+	backend := testUploads(t)
+	s := testStore(t, config.Quota{Group: config.QuotaLimit{Objects: 1}}, backend)
+	ctx := context.Background()
+	group := testGroup(t, s, "team")
+
+	require.NoError(t, s.Groups.SaveAvatar(ctx, group, bytes.NewBufferString("first")))
+	first := group.Avatar
+
+	require.NoError(t, s.Groups.SaveAvatar(ctx, group, bytes.NewBufferString("second")))
+	assert.NotEqual(t, first, group.Avatar)
+
+	_, err := backend.Stat(ctx, first)
+	assert.Error(t, err)
+
+	_, err = backend.Stat(ctx, group.Avatar)
+	assert.NoError(t, err)
+
+	tracked := make([]*model.Upload, 0)
+	require.NoError(t, s.Handle().NewSelect().
+		Model(&tracked).
+		Scan(ctx))
+
+	require.Len(t, tracked, 1)
+	assert.Equal(t, group.Avatar, tracked[0].Path)
+	assert.Equal(t, group.ID, tracked[0].GroupID)
+
+	current := group.Avatar
+	require.NoError(t, s.Groups.DeleteAvatar(ctx, group))
+	assert.Empty(t, group.Avatar)
+
+	_, err = backend.Stat(ctx, current)
+	assert.Error(t, err)
+
+	count, err := s.Handle().NewSelect().
+		Model((*model.Upload)(nil)).
+		Count(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
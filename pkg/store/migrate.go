@@ -0,0 +1,296 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gopad/gopad-api/pkg/migrations"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/migrate"
+)
+
+const (
+	migrationsTable = "bun_migrations"
+)
+
+// MigrateOptions defines the optional parameters for migrate and rollback.
+type MigrateOptions struct {
+	// Target defines the migration to migrate up to or to rollback to.
+	Target string
+
+	// Steps defines the number of migration groups to rollback.
+	Steps int
+
+	// DryRun records the statements instead of executing them.
+	DryRun bool
+}
+
+// Migrator provides the migration client.
+func (s *Store) Migrator(ctx context.Context) (*migrate.Migrator, error) {
+	migrator := migrate.NewMigrator(
+		s.handle,
+		migrations.Migrations,
+	)
+
+	if err := migrator.Init(ctx); err != nil {
+		return nil, err
+	}
+
+	return migrator, nil
+}
+
+// Pending returns all migrations which have not been applied yet, it never
+// creates the tables to track the migrations.
+func (s *Store) Pending(ctx context.Context) (migrate.MigrationSlice, error) {
+	migrator, err := s.migrator(ctx, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	all, err := s.migrationsWithStatus(ctx, migrator, true)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return all.Unapplied(), nil
+}
+
+// Migrate handles a database migration, optionally up to a target.
+func (s *Store) Migrate(ctx context.Context, opts MigrateOptions) (*migrate.MigrationGroup, []string, error) {
+	migrator, err := s.migrator(ctx, opts.DryRun)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !opts.DryRun {
+		if err := migrator.Lock(ctx); err != nil {
+			return nil, nil, err
+		}
+
+		defer func() {
+			_ = migrator.Unlock(ctx)
+		}()
+	}
+
+	all, err := s.migrationsWithStatus(ctx, migrator, opts.DryRun)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pending := all.Unapplied()
+
+	if opts.Target != "" {
+		target, err := findMigration(all, opts.Target)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for i, row := range pending {
+			if row.Name > target.Name {
+				pending = pending[:i]
+				break
+			}
+		}
+	}
+
+	group := &migrate.MigrationGroup{}
+
+	if len(pending) == 0 {
+		return group, nil, nil
+	}
+
+	runner, recorder := s.runner(migrator, opts.DryRun)
+	group.ID = all.LastGroupID() + 1
+
+	for i := range pending {
+		migration := &pending[i]
+		migration.GroupID = group.ID
+
+		if migration.Up != nil {
+			recorder.comment("up", migration)
+
+			if err := migration.Up(ctx, runner, migration); err != nil {
+				return group, recorder.list(), fmt.Errorf("%s: up: %w", migration.Name, err)
+			}
+		}
+
+		if !opts.DryRun {
+			if err := migrator.MarkApplied(ctx, migration); err != nil {
+				return group, nil, err
+			}
+		}
+
+		group.Migrations = pending[:i+1]
+	}
+
+	return group, recorder.list(), nil
+}
+
+// Rollback handles a database rollback, by steps or down to a target.
+func (s *Store) Rollback(ctx context.Context, opts MigrateOptions) (*migrate.MigrationGroup, []string, error) {
+	migrator, err := s.migrator(ctx, opts.DryRun)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !opts.DryRun {
+		if err := migrator.Lock(ctx); err != nil {
+			return nil, nil, err
+		}
+
+		defer func() {
+			_ = migrator.Unlock(ctx)
+		}()
+	}
+
+	all, err := s.migrationsWithStatus(ctx, migrator, opts.DryRun)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applied := all.Applied()
+	selected := make(migrate.MigrationSlice, 0)
+
+	if opts.Target != "" {
+		target, err := findMigration(all, opts.Target)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, row := range applied {
+			if row.Name > target.Name {
+				selected = append(selected, row)
+			}
+		}
+	} else {
+		steps := max(opts.Steps, 1)
+		groups := make(map[int64]struct{}, steps)
+
+		for _, row := range applied {
+			if _, ok := groups[row.GroupID]; !ok {
+				if len(groups) == steps {
+					break
+				}
+
+				groups[row.GroupID] = struct{}{}
+			}
+
+			selected = append(selected, row)
+		}
+	}
+
+	group := &migrate.MigrationGroup{}
+
+	if len(selected) == 0 {
+		return group, nil, nil
+	}
+
+	runner, recorder := s.runner(migrator, opts.DryRun)
+	group.ID = selected[0].GroupID
+
+	for i := range selected {
+		migration := &selected[i]
+
+		if migration.Down != nil {
+			recorder.comment("down", migration)
+
+			if err := migration.Down(ctx, runner, migration); err != nil {
+				return group, recorder.list(), fmt.Errorf("%s: down: %w", migration.Name, err)
+			}
+		}
+
+		if !opts.DryRun {
+			if err := migrator.MarkUnapplied(ctx, migration); err != nil {
+				return group, nil, err
+			}
+		}
+
+		group.Migrations = selected[:i+1]
+	}
+
+	return group, recorder.list(), nil
+}
+
+// migrator skips the initialization for read-only usage like dry runs,
+// otherwise even a dry run would create the tables to track the migrations.
+func (s *Store) migrator(ctx context.Context, readOnly bool) (*migrate.Migrator, error) {
+	if !readOnly {
+		return s.Migrator(ctx)
+	}
+
+	return migrate.NewMigrator(
+		s.handle,
+		migrations.Migrations,
+	), nil
+}
+
+// migrationsWithStatus treats a missing migrations table as nothing applied
+// for read-only usage, the table is only created by an actual migration.
+func (s *Store) migrationsWithStatus(ctx context.Context, migrator *migrate.Migrator, readOnly bool) (migrate.MigrationSlice, error) {
+	if readOnly {
+		exists, err := s.tableExists(ctx, migrationsTable)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !exists {
+			return migrations.Migrations.Sorted(), nil
+		}
+	}
+
+	return migrator.MigrationsWithStatus(ctx)
+}
+
+func (s *Store) tableExists(ctx context.Context, name string) (bool, error) {
+	var query string
+
+	switch s.handle.Dialect().Name() {
+	case dialect.SQLite:
+		query = "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?"
+	case dialect.PG:
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = CURRENT_SCHEMA() AND table_name = ?"
+	default:
+		query = "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?"
+	}
+
+	count := 0
+
+	if err := s.handle.NewRaw(query, name).Scan(ctx, &count); err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (s *Store) runner(migrator *migrate.Migrator, dryRun bool) (*migrate.Migrator, *dryRunRecorder) {
+	if !dryRun {
+		return migrator, nil
+	}
+
+	recorder := newDryRunRecorder(s.handle.DB)
+
+	return migrate.NewMigrator(
+		recorder.bun(s.handle),
+		migrations.Migrations,
+	), recorder
+}
+
+func findMigration(all migrate.MigrationSlice, name string) (*migrate.Migration, error) {
+	for i, row := range all {
+		if row.Name == name || row.String() == name || strings.HasPrefix(row.String(), name+"_") {
+			return &all[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrMigrationNotFound, name)
+}
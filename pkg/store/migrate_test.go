@@ -0,0 +1,114 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/gopad/gopad-api/pkg/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDryRun(t *testing.T) {
+	s := testEmptyStore(t, config.Quota{}, nil)
+	ctx := context.Background()
+	total := len(migrations.Migrations.Sorted())
+
+	group, statements, err := s.Migrate(ctx, MigrateOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Len(t, group.Migrations, total)
+	assert.NotEmpty(t, statements)
+
+	for _, table := range []string{migrationsTable, "bun_migration_locks", "users"} {
+		exists, err := s.tableExists(ctx, table)
+		require.NoError(t, err)
+		assert.False(t, exists, table)
+	}
+
+	group, statements, err = s.Rollback(ctx, MigrateOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, group.IsZero())
+	assert.Empty(t, statements)
+}
+
+func TestPendingWithoutTable(t *testing.T) {
+	s := testEmptyStore(t, config.Quota{}, nil)
+	ctx := context.Background()
+
+	pending, err := s.Pending(ctx)
+	require.NoError(t, err)
+	assert.Len(t, pending, len(migrations.Migrations.Sorted()))
+
+	for _, table := range []string{migrationsTable, "bun_migration_locks"} {
+		exists, err := s.tableExists(ctx, table)
+		require.NoError(t, err)
+		assert.False(t, exists, table)
+	}
+}
+
+func TestMigrateTarget(t *testing.T) {
+	s := testEmptyStore(t, config.Quota{}, nil)
+	ctx := context.Background()
+	total := len(migrations.Migrations.Sorted())
+
+	group, _, err := s.Migrate(ctx, MigrateOptions{Target: "20241230185719"})
+	require.NoError(t, err)
+	assert.Len(t, group.Migrations, 2)
+
+	pending, err := s.Pending(ctx)
+	require.NoError(t, err)
+	assert.Len(t, pending, total-2)
+
+	group, _, err = s.Migrate(ctx, MigrateOptions{DryRun: true})
+	require.NoError(t, err)
+	assert.Len(t, group.Migrations, total-2)
+
+	pending, err = s.Pending(ctx)
+	require.NoError(t, err)
+	assert.Len(t, pending, total-2)
+
+	_, _, err = s.Migrate(ctx, MigrateOptions{Target: "missing"})
+	assert.ErrorIs(t, err, ErrMigrationNotFound)
+}
+
+func TestRollback(t *testing.T) {
+	total := len(migrations.Migrations.Sorted())
+
+	tests := []struct {
+		name       string
+		opts       MigrateOptions
+		rolledBack int
+	}{
+		{name: "default step", opts: MigrateOptions{}, rolledBack: total - 2},
+		{name: "two steps", opts: MigrateOptions{Steps: 2}, rolledBack: total},
+		{name: "target", opts: MigrateOptions{Target: "20241230185629"}, rolledBack: total - 1},
+		{name: "dry run", opts: MigrateOptions{DryRun: true}, rolledBack: total - 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testEmptyStore(t, config.Quota{}, nil)
+			ctx := context.Background()
+
+			_, _, err := s.Migrate(ctx, MigrateOptions{Target: "20241230185719"})
+			require.NoError(t, err)
+
+			_, _, err = s.Migrate(ctx, MigrateOptions{})
+			require.NoError(t, err)
+
+			group, _, err := s.Rollback(ctx, tt.opts)
+			require.NoError(t, err)
+			assert.Len(t, group.Migrations, tt.rolledBack)
+
+			pending, err := s.Pending(ctx)
+			require.NoError(t, err)
+
+			if tt.opts.DryRun {
+				assert.Empty(t, pending)
+			} else {
+				assert.Len(t, pending, tt.rolledBack)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/uptrace/bun"
+)
+
+// Notifications provides all database operations related to notifications.
+type Notifications struct {
+	client *Store
+}
+
+// List implements the listing of all notifications for an user.
+func (s *Notifications) List(ctx context.Context, params model.NotificationParams) ([]*model.Notification, model.ListMeta, error) {
+	records := make([]*model.Notification, 0)
+
+	q := s.client.reader().NewSelect().
+		Model(&records).
+		Where("notification.user_id = ?", params.UserID)
+
+	if params.Unread {
+		q = q.Where("notification.read_at IS NULL")
+	}
+
+	sort, _ := s.ValidSort(params.Sort)
+	meta, err := paginate(
+		ctx,
+		q,
+		&records,
+		params.ListParams,
+		sort,
+		"notification.id",
+		(*model.Notification)(nil),
+		func(r *model.Notification) any { return r },
+	)
+
+	if err != nil {
+		return nil, meta, err
+	}
+
+	return records, meta, nil
+}
+
+// Unread implements the counting of unread notifications for an user.
+func (s *Notifications) Unread(ctx context.Context, userID string) (int64, error) {
+	counter, err := s.client.reader().NewSelect().
+		Model((*model.Notification)(nil)).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(ctx)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(counter), nil
+}
+
+// Read implements marking a specific notification of an user as read.
+func (s *Notifications) Read(ctx context.Context, userID, id string) (*model.Notification, error) {
+	record := &model.Notification{}
+
+	if err := s.client.handle.NewSelect().
+		Model(record).
+		Where("id = ? AND user_id = ?", id, userID).
+		Scan(ctx); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return record, ErrNotificationNotFound
+		}
+
+		return record, err
+	}
+
+	if !record.ReadAt.IsZero() {
+		return record, nil
+	}
+
+	record.ReadAt = time.Now()
+
+	if _, err := s.client.handle.NewUpdate().
+		Model(record).
+		Column("read_at", "updated_at").
+		WherePK().
+		Exec(ctx); err != nil {
+		return record, err
+	}
+
+	return record, nil
+}
+
+// ReadAll implements marking all notifications of an user as read.
+func (s *Notifications) ReadAll(ctx context.Context, userID string) error {
+	now := time.Now()
+
+	if _, err := s.client.handle.NewUpdate().
+		Model((*model.Notification)(nil)).
+		Set("read_at = ?", now).
+		Set("updated_at = ?", now).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Digest collects the unread notifications which have not been mailed yet for
+// all active users with an enabled digest. Every successfully sent digest
+// marks the included notifications as mailed, it returns the sent digests.
+func (s *Notifications) Digest(ctx context.Context, send func(context.Context, *model.User, []*model.Notification) error) (int, error) {
+	pending := make([]string, 0)
+
+	if err := s.client.handle.NewSelect().
+		Model((*model.Notification)(nil)).
+		Distinct().
+		Column("user_id").
+		Where("read_at IS NULL AND mailed_at IS NULL").
+		Scan(ctx, &pending); err != nil {
+		return 0, err
+	}
+
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	users := make([]*model.User, 0)
+
+	if err := s.client.handle.NewSelect().
+		Model(&users).
+		Where("id IN (?)", bun.In(pending)).
+		Where("digest = ? AND active = ?", true, true).
+		Where("email != ''").
+		Scan(ctx); err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	errs := make([]error, 0)
+
+	for _, user := range users {
+		records := make([]*model.Notification, 0)
+
+		if err := s.client.handle.NewSelect().
+			Model(&records).
+			Where("user_id = ? AND read_at IS NULL AND mailed_at IS NULL", user.ID).
+			Order("created_at ASC").
+			Scan(ctx); err != nil {
+			return sent, err
+		}
+
+		if len(records) == 0 {
+			continue
+		}
+
+		if err := send(ctx, user, records); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", user.Username, err))
+			continue
+		}
+
+		ids := make([]string, 0, len(records))
+
+		for _, record := range records {
+			ids = append(ids, record.ID)
+		}
+
+		if _, err := s.client.handle.NewUpdate().
+			Model((*model.Notification)(nil)).
+			Set("mailed_at = ?", time.Now()).
+			Where("id IN (?)", bun.In(ids)).
+			Exec(ctx); err != nil {
+			return sent, err
+		}
+
+		sent++
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+// ValidSort validates the sort column for notifications.
+func (s *Notifications) ValidSort(val string) (string, bool) {
+	if val == "" {
+		return "notification.created_at", true
+	}
+
+	val = strings.ToLower(val)
+
+	for key, name := range map[string]string{
+		"kind":    "notification.kind",
+		"created": "notification.created_at",
+		"updated": "notification.updated_at",
+	} {
+		if val == key {
+			return name, true
+		}
+	}
+
+	return "notification.created_at", true
+}
+
+func (s *Notifications) groupAttached(ctx context.Context, db bun.IDB, user *model.User, group *model.Group, perm string) error {
+	return s.notify(ctx, db, &model.Notification{
+		UserID:    user.ID,
+		Kind:      model.NotificationKindGroupAttached,
+		Reference: group.ID,
+		Message:   fmt.Sprintf("You have been added to the group %s as %s", group.Name, perm),
+	})
+}
+
+func (s *Notifications) notify(ctx context.Context, db bun.IDB, record *model.Notification) error {
+	if _, err := db.NewInsert().
+		Model(record).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachNotifies(t *testing.T) {
+	tests := []struct {
+		name   string
+		attach func(*Store, context.Context, model.UserGroupParams) error
+	}{
+		{
+			name: "user attach group",
+			attach: func(s *Store, ctx context.Context, params model.UserGroupParams) error {
+				return s.Users.AttachGroup(ctx, params)
+			},
+		},
+		{
+			name: "group attach user",
+			attach: func(s *Store, ctx context.Context, params model.UserGroupParams) error {
+				return s.Groups.AttachUser(ctx, params)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := testStore(t, config.Quota{}, nil)
+			ctx := context.Background()
+			user := testUser(t, s, "jdoe")
+			group := testGroup(t, s, "team")
+
+			params := model.UserGroupParams{
+				UserID:  user.ID,
+				GroupID: group.ID,
+				Perm:    model.UserGroupAdminPerm,
+			}
+
+			require.NoError(t, tt.attach(s, ctx, params))
+			assert.ErrorIs(t, tt.attach(s, ctx, params), ErrAlreadyAssigned)
+
+			records, _, err := s.Notifications.List(ctx, model.NotificationParams{
+				UserID: user.ID,
+			})
+
+			require.NoError(t, err)
+			require.Len(t, records, 1)
+			assert.Equal(t, model.NotificationKindGroupAttached, records[0].Kind)
+			assert.Equal(t, group.ID, records[0].Reference)
+			assert.Equal(t, "You have been added to the group team as admin", records[0].Message)
+			assert.True(t, records[0].ReadAt.IsZero())
+		})
+	}
+}
+
+func TestNotifications(t *testing.T) {
+	s := testStore(t, config.Quota{}, nil)
+	ctx := context.Background()
+	user := testUser(t, s, "jdoe")
+	other := testUser(t, s, "other")
+
+	for _, slug := range []string{"alpha", "beta", "gamma"} {
+		require.NoError(t, s.Users.AttachGroup(ctx, model.UserGroupParams{
+			UserID:  user.ID,
+			GroupID: testGroup(t, s, slug).ID,
+			Perm:    model.UserGroupUserPerm,
+		}))
+	}
+
+	list := func(unread bool) []*model.Notification {
+		records, _, err := s.Notifications.List(ctx, model.NotificationParams{
+			UserID: user.ID,
+			Unread: unread,
+		})
+
+		require.NoError(t, err)
+		return records
+	}
+
+	unread := func() int64 {
+		count, err := s.Notifications.Unread(ctx, user.ID)
+		require.NoError(t, err)
+
+		return count
+	}
+
+	records := list(false)
+	require.Len(t, records, 3)
+	assert.Equal(t, int64(3), unread())
+
+	_, err := s.Notifications.Read(ctx, other.ID, records[0].ID)
+	assert.ErrorIs(t, err, ErrNotificationNotFound)
+
+	_, err = s.Notifications.Read(ctx, user.ID, "missing")
+	assert.ErrorIs(t, err, ErrNotificationNotFound)
+
+	record, err := s.Notifications.Read(ctx, user.ID, records[0].ID)
+	require.NoError(t, err)
+	assert.False(t, record.ReadAt.IsZero())
+
+	stored, err := s.Notifications.Read(ctx, user.ID, records[0].ID)
+	require.NoError(t, err)
+
+	record, err = s.Notifications.Read(ctx, user.ID, records[0].ID)
+	require.NoError(t, err)
+	assert.True(t, stored.ReadAt.Equal(record.ReadAt))
+
+	assert.Equal(t, int64(2), unread())
+	assert.Len(t, list(true), 2)
+	assert.Len(t, list(false), 3)
+
+	require.NoError(t, s.Notifications.ReadAll(ctx, user.ID))
+	assert.Equal(t, int64(0), unread())
+	assert.Empty(t, list(true))
+	assert.Len(t, list(false), 3)
+
+	count, err := s.Notifications.Unread(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestNotificationDigest(t *testing.T) {
+	s := testStore(t, config.Quota{}, nil)
+	ctx := context.Background()
+	user := testUser(t, s, "jdoe")
+	other := testUser(t, s, "other")
+
+	user.Digest = true
+	require.NoError(t, s.Users.Update(ctx, user))
+
+	for _, slug := range []string{"alpha", "beta", "gamma"} {
+		group := testGroup(t, s, slug)
+
+		for _, id := range []string{user.ID, other.ID} {
+			require.NoError(t, s.Users.AttachGroup(ctx, model.UserGroupParams{
+				UserID:  id,
+				GroupID: group.ID,
+				Perm:    model.UserGroupUserPerm,
+			}))
+		}
+	}
+
+	records, _, err := s.Notifications.List(ctx, model.NotificationParams{
+		UserID: user.ID,
+	})
+
+	require.NoError(t, err)
+	_, err = s.Notifications.Read(ctx, user.ID, records[0].ID)
+	require.NoError(t, err)
+
+	failing := func(_ context.Context, _ *model.User, _ []*model.Notification) error {
+		return assert.AnError
+	}
+
+	sent, err := s.Notifications.Digest(ctx, failing)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 0, sent)
+
+	delivered := make(map[string][]*model.Notification)
+
+	collect := func(_ context.Context, user *model.User, records []*model.Notification) error {
+		delivered[user.Username] = records
+		return nil
+	}
+
+	sent, err = s.Notifications.Digest(ctx, collect)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	require.Contains(t, delivered, "jdoe")
+	assert.NotContains(t, delivered, "other")
+	assert.Len(t, delivered["jdoe"], 2)
+
+	for _, record := range delivered["jdoe"] {
+		assert.NotEqual(t, records[0].ID, record.ID)
+	}
+
+	delivered = make(map[string][]*model.Notification)
+
+	sent, err = s.Notifications.Digest(ctx, collect)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	assert.Empty(t, delivered)
+
+	count, err := s.Notifications.Unread(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+}
@@ -0,0 +1,211 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect/mysqldialect"
+	"github.com/uptrace/bun/dialect/pgdialect"
+	"github.com/uptrace/bun/dialect/sqlitedialect"
+	"github.com/uptrace/bun/driver/pgdriver"
+	"github.com/uptrace/bun/driver/sqliteshim"
+	"github.com/uptrace/bun/extra/bunzerolog"
+)
+
+// replica defines a read-only database connection.
+type replica struct {
+	index   int
+	handle  *bun.DB
+	healthy atomic.Bool
+	checked bool
+}
+
+// reader returns a healthy replica handle in a round-robin manner, or the
+// primary handle if no replica is configured or all of them are unhealthy.
+func (s *Store) reader() *bun.DB {
+	count := len(s.replicas)
+
+	if count == 0 {
+		return s.handle
+	}
+
+	start := int(s.replicaNext.Add(1) % uint64(count))
+
+	for i := 0; i < count; i++ {
+		if r := s.replicas[(start+i)%count]; r.healthy.Load() {
+			return r.handle
+		}
+	}
+
+	return s.handle
+}
+
+// openReplicas closes previously opened replicas first, that way retrying an
+// open neither leaks connections nor health checks.
+func (s *Store) openReplicas() error {
+	if err := s.closeReplicas(); err != nil {
+		return err
+	}
+
+	s.replicas = make([]*replica, 0, len(s.replicaDSNs))
+
+	for i, dsn := range s.replicaDSNs {
+		handle, err := s.openReplica(dsn)
+
+		if err != nil {
+			return err
+		}
+
+		handle.AddQueryHook(
+			bunzerolog.NewQueryHook(
+				bunzerolog.WithQueryLogLevel(zerolog.TraceLevel),
+				bunzerolog.WithSlowQueryLogLevel(zerolog.WarnLevel),
+				bunzerolog.WithErrorQueryLogLevel(zerolog.ErrorLevel),
+				bunzerolog.WithSlowQueryThreshold(3*time.Second),
+			),
+		)
+
+		switch s.driver {
+		case "mysql", "mariadb", "postgres", "postgresql":
+			handle.SetMaxOpenConns(s.maxOpenConns)
+			handle.SetMaxIdleConns(s.maxIdleConns)
+			handle.SetConnMaxLifetime(s.connMaxLifetime)
+		}
+
+		s.replicas = append(s.replicas, &replica{
+			index:  i,
+			handle: handle,
+		})
+	}
+
+	if len(s.replicas) == 0 {
+		return nil
+	}
+
+	replicas := s.replicas
+	s.checkReplicas(context.Background(), replicas)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	s.replicaStop = func() {
+		cancel()
+		<-done
+	}
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(s.replicaCheck)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.checkReplicas(ctx, replicas)
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *Store) openReplica(dsn string) (*bun.DB, error) {
+	switch s.driver {
+	case "sqlite", "sqlite3":
+		sqldb, err := sql.Open(
+			sqliteshim.ShimName,
+			dsn,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return bun.NewDB(
+			sqldb,
+			sqlitedialect.New(),
+		), nil
+	case "mysql", "mariadb":
+		sqldb, err := sql.Open(
+			"mysql",
+			dsn,
+		)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return bun.NewDB(
+			sqldb,
+			mysqldialect.New(),
+		), nil
+	case "postgres", "postgresql":
+		sqldb := sql.OpenDB(
+			pgdriver.NewConnector(
+				pgdriver.WithDSN(dsn),
+			),
+		)
+
+		return bun.NewDB(
+			sqldb,
+			pgdialect.New(),
+		), nil
+	}
+
+	return nil, ErrUnknownDriver
+}
+
+func (s *Store) checkReplicas(ctx context.Context, replicas []*replica) {
+	for _, r := range replicas {
+		ctx, cancel := context.WithTimeout(ctx, s.replicaCheck)
+		err := r.handle.PingContext(ctx)
+		cancel()
+
+		healthy := err == nil
+
+		previous := r.healthy.Swap(healthy)
+
+		if r.checked && previous == healthy {
+			continue
+		}
+
+		r.checked = true
+
+		if healthy {
+			log.Info().
+				Int("replica", r.index).
+				Msg("Database replica is healthy")
+		} else {
+			log.Warn().
+				Err(err).
+				Int("replica", r.index).
+				Msg("Database replica is unhealthy, falling back")
+		}
+	}
+}
+
+func (s *Store) closeReplicas() error {
+	if s.replicaStop != nil {
+		s.replicaStop()
+		s.replicaStop = nil
+	}
+
+	replicas := s.replicas
+	s.replicas = nil
+
+	for _, r := range replicas {
+		if err := r.handle.Close(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicaRouting(t *testing.T) {
+	ctx := context.Background()
+	primary := testStore(t, config.Quota{}, nil)
+	user := testUser(t, primary, "jdoe")
+
+	lagging := testStore(t, config.Quota{}, nil)
+	stale := *user
+
+	_, err := lagging.Handle().NewInsert().
+		Model(&stale).
+		Exec(ctx)
+
+	require.NoError(t, err)
+
+	user.Email = "updated@example.com"
+	require.NoError(t, primary.Users.Update(ctx, user))
+
+	tests := []struct {
+		name     string
+		replicas []string
+		show     string
+		primary  string
+	}{
+		{
+			name:     "without replicas",
+			replicas: nil,
+			show:     "updated@example.com",
+			primary:  "updated@example.com",
+		},
+		{
+			name:     "lagging replica",
+			replicas: []string{lagging.database},
+			show:     "jdoe@example.com",
+			primary:  "updated@example.com",
+		},
+		{
+			name:     "unhealthy replica",
+			replicas: []string{filepath.Join(t.TempDir(), "missing", "replica.sqlite3")},
+			show:     "updated@example.com",
+			primary:  "updated@example.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewStore(
+				config.Database{
+					Driver:   "sqlite3",
+					Name:     primary.database,
+					Replicas: tt.replicas,
+				},
+				config.Scim{},
+				config.Quota{},
+				nil,
+			)
+
+			require.NoError(t, err)
+
+			_, err = s.Open()
+			require.NoError(t, err)
+
+			defer func() { _, _ = s.Close() }()
+
+			record, err := s.Users.Show(ctx, "jdoe")
+			require.NoError(t, err)
+			assert.Equal(t, tt.show, record.Email)
+
+			record, err = s.Users.ShowPrimary(ctx, "jdoe")
+			require.NoError(t, err)
+			assert.Equal(t, tt.primary, record.Email)
+		})
+	}
+}
+
+func TestReplicaReopen(t *testing.T) {
+	ctx := context.Background()
+	primary := testStore(t, config.Quota{}, nil)
+	replica := testStore(t, config.Quota{}, nil)
+	testUser(t, primary, "jdoe")
+	testUser(t, replica, "jdoe")
+
+	s, err := NewStore(
+		config.Database{
+			Driver:   "sqlite3",
+			Name:     primary.database,
+			Replicas: []string{replica.database},
+		},
+		config.Scim{},
+		config.Quota{},
+		nil,
+	)
+
+	require.NoError(t, err)
+
+	_, err = s.Open()
+	require.NoError(t, err)
+
+	handle := s.handle
+	first := s.replicas[0].handle
+
+	_, err = s.Open()
+	require.NoError(t, err)
+
+	defer func() { _, _ = s.Close() }()
+
+	assert.Error(t, handle.Ping())
+	assert.Error(t, first.Ping())
+	assert.Len(t, s.replicas, 1)
+	assert.NotSame(t, first, s.replicas[0].handle)
+
+	_, err = s.Users.Show(ctx, "jdoe")
+	require.NoError(t, err)
+
+	_, err = s.Close()
+	require.NoError(t, err)
+
+	assert.Nil(t, s.replicaStop)
+	assert.Empty(t, s.replicas)
+}
@@ -8,10 +8,10 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gopad/gopad-api/pkg/config"
-	"github.com/gopad/gopad-api/pkg/migrations"
 	"github.com/gopad/gopad-api/pkg/model"
 	"github.com/gopad/gopad-api/pkg/upload"
 	"github.com/rs/zerolog"
@@ -22,7 +22,6 @@ import (
 	"github.com/uptrace/bun/driver/pgdriver"
 	"github.com/uptrace/bun/driver/sqliteshim"
 	"github.com/uptrace/bun/extra/bunzerolog"
-	"github.com/uptrace/bun/migrate"
 
 	// generally import the mysql driver.
 	_ "github.com/go-sql-driver/mysql"
@@ -51,9 +50,17 @@ type Store struct {
 	handle          *bun.DB
 	principal       *model.User
 
-	Auth   *Auth
-	Groups *Groups
-	Users  *Users
+	replicaDSNs  []string
+	replicaCheck time.Duration
+	replicaNext  atomic.Uint64
+	replicaStop  func()
+	replicas     []*replica
+
+	Auth          *Auth
+	Groups        *Groups
+	Notifications *Notifications
+	Uploads       *Uploads
+	Users         *Users
 }
 
 // Handle returns a database handle.
@@ -164,6 +171,10 @@ func (s *Store) Info() map[string]interface{} {
 		result["username"] = s.username
 	}
 
+	if len(s.replicaDSNs) > 0 {
+		result["replicas"] = len(s.replicaDSNs)
+	}
+
 	return result
 }
 
@@ -190,6 +201,10 @@ func (s *Store) Prepare() error {
 
 // Open simply opens the database connection.
 func (s *Store) Open() (bool, error) {
+	if s.handle != nil {
+		_ = s.handle.Close()
+	}
+
 	err := s.open()
 
 	if err != nil {
@@ -209,11 +224,19 @@ func (s *Store) Open() (bool, error) {
 		return false, err
 	}
 
+	if err = s.openReplicas(); err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
 // Close simply closes the database connection.
 func (s *Store) Close() (bool, error) {
+	if err := s.closeReplicas(); err != nil {
+		return false, err
+	}
+
 	if s.handle != nil {
 		if err := s.handle.Close(); err != nil {
 			return false, err
@@ -234,58 +257,6 @@ func (s *Store) Ping() (bool, error) {
 	return true, nil
 }
 
-// Migrator provides the migration client.
-func (s *Store) Migrator(ctx context.Context) (*migrate.Migrator, error) {
-	migrator := migrate.NewMigrator(
-		s.handle,
-		migrations.Migrations,
-	)
-
-	if err := migrator.Init(ctx); err != nil {
-		return nil, err
-	}
-
-	return migrator, nil
-}
-
-// Migrate handles a database migration.
-func (s *Store) Migrate(ctx context.Context) (*migrate.MigrationGroup, error) {
-	migrator, err := s.Migrator(ctx)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := migrator.Lock(ctx); err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		_ = migrator.Unlock(ctx)
-	}()
-
-	return migrator.Migrate(ctx)
-}
-
-// Rollback handles a database rollback.
-func (s *Store) Rollback(ctx context.Context) (*migrate.MigrationGroup, error) {
-	migrator, err := s.Migrator(ctx)
-
-	if err != nil {
-		return nil, err
-	}
-
-	if err := migrator.Lock(ctx); err != nil {
-		return nil, err
-	}
-
-	defer func() {
-		_ = migrator.Unlock(ctx)
-	}()
-
-	return migrator.Rollback(ctx)
-}
-
 func (s *Store) open() error {
 	switch s.driver {
 	case "sqlite", "sqlite3":
@@ -401,7 +372,7 @@ func (s *Store) open() error {
 }
 
 // NewStore initializes a new Bun
-func NewStore(cfg config.Database, scim config.Scim, uploads upload.Upload) (*Store, error) {
+func NewStore(cfg config.Database, scim config.Scim, quota config.Quota, uploads upload.Upload) (*Store, error) {
 	username, err := config.Value(cfg.Username)
 
 	if err != nil {
@@ -466,6 +437,32 @@ func NewStore(cfg config.Database, scim config.Scim, uploads upload.Upload) (*St
 		client.connMaxLifetime = 5 * time.Minute
 	}
 
+	if val, ok := cfg.Options["replicaCheck"]; ok {
+		cur, err := time.ParseDuration(
+			val,
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse replicaCheck: %w", err)
+		}
+
+		client.replicaCheck = cur
+	} else {
+		client.replicaCheck = 10 * time.Second
+	}
+
+	for _, val := range cfg.Replicas {
+		dsn, err := config.Value(val)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse replica secret: %w", err)
+		}
+
+		if dsn = strings.TrimSpace(dsn); dsn != "" {
+			client.replicaDSNs = append(client.replicaDSNs, dsn)
+		}
+	}
+
 	switch client.driver {
 	case "sqlite", "sqlite3":
 		client.driver = "sqlite3"
@@ -526,6 +523,22 @@ func NewStore(cfg config.Database, scim config.Scim, uploads upload.Upload) (*St
 		client: client,
 	}
 
+	client.Notifications = &Notifications{
+		client: client,
+	}
+
+	client.Uploads = &Uploads{
+		client: client,
+		quota:  quota,
+	}
+
+	if uploads != nil {
+		client.upload = &trackedUpload{
+			backend: uploads,
+			uploads: client.Uploads,
+		}
+	}
+
 	client.Users = &Users{
 		client: client,
 	}
@@ -534,8 +547,8 @@ func NewStore(cfg config.Database, scim config.Scim, uploads upload.Upload) (*St
 }
 
 // MustStore simply calls NewStore and panics on an error.
-func MustStore(cfg config.Database, scim config.Scim, uploads upload.Upload) *Store {
-	s, err := NewStore(cfg, scim, uploads)
+func MustStore(cfg config.Database, scim config.Scim, quota config.Quota, uploads upload.Upload) *Store {
+	s, err := NewStore(cfg, scim, quota, uploads)
 
 	if err != nil {
 		panic(err)
@@ -0,0 +1,93 @@
+package store
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/gopad/gopad-api/pkg/upload"
+	"github.com/stretchr/testify/require"
+)
+
+func testStore(t *testing.T, quota config.Quota, uploads upload.Upload) *Store {
+	t.Helper()
+
+	s := testEmptyStore(t, quota, uploads)
+
+	_, _, err := s.Migrate(context.Background(), MigrateOptions{})
+	require.NoError(t, err)
+
+	return s
+}
+
+func testUploads(t *testing.T) upload.Upload {
+	t.Helper()
+
+	backend, err := upload.NewFileUpload(config.Upload{
+		Path: filepath.Join(t.TempDir(), "uploads"),
+	})
+
+	require.NoError(t, err)
+
+	_, err = backend.Prepare()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = backend.Close()
+	})
+
+	return backend
+}
+
+func testEmptyStore(t *testing.T, quota config.Quota, uploads upload.Upload) *Store {
+	t.Helper()
+
+	s, err := NewStore(
+		config.Database{
+			Driver: "sqlite3",
+			Name:   filepath.Join(t.TempDir(), "gopad.sqlite3"),
+		},
+		config.Scim{},
+		quota,
+		uploads,
+	)
+
+	require.NoError(t, err)
+
+	_, err = s.Open()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = s.Close()
+	})
+
+	return s
+}
+
+func testUser(t *testing.T, s *Store, username string) *model.User {
+	t.Helper()
+
+	record := &model.User{
+		Username: username,
+		Password: "p455w0rd",
+		Email:    username + "@example.com",
+		Active:   true,
+	}
+
+	require.NoError(t, s.Users.Create(context.Background(), record))
+	return record
+}
+
+func testGroup(t *testing.T, s *Store, slug string) *model.Group {
+	t.Helper()
+
+	record := &model.Group{
+		Slug: slug,
+		Name: slug,
+	}
+
+	require.NoError(t, s.Groups.Create(context.Background(), record))
+	return record
+}
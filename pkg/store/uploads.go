@@ -0,0 +1,473 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/gopad/gopad-api/pkg/upload"
+	"github.com/uptrace/bun"
+)
+
+// cleanupPrefixes defines the prefixes of the upload backend which are
+// owned by the application, objects outside of them never get removed.
+var cleanupPrefixes = []string{
+	"avatars/",
+}
+
+// Uploads provides all database operations related to stored uploads.
+type Uploads struct {
+	client *Store
+	quota  config.Quota
+}
+
+// Quota returns the configured storage quota.
+func (s *Uploads) Quota() config.Quota {
+	return s.quota
+}
+
+// UserUsage aggregates the storage usage of all users owning uploads.
+func (s *Uploads) UserUsage(ctx context.Context) ([]*model.Usage, error) {
+	records := make([]*model.Usage, 0)
+
+	if err := s.client.reader().NewSelect().
+		Model((*model.Upload)(nil)).
+		ColumnExpr("upload.user_id AS id").
+		ColumnExpr("owner.username AS name").
+		ColumnExpr("SUM(upload.size) AS bytes").
+		ColumnExpr("COUNT(upload.id) AS objects").
+		Join("JOIN users AS owner ON owner.id = upload.user_id").
+		Group("upload.user_id", "owner.username").
+		Order("owner.username ASC").
+		Scan(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// GroupUsage aggregates the storage usage of all groups owning uploads.
+func (s *Uploads) GroupUsage(ctx context.Context) ([]*model.Usage, error) {
+	records := make([]*model.Usage, 0)
+
+	if err := s.client.reader().NewSelect().
+		Model((*model.Upload)(nil)).
+		ColumnExpr("upload.group_id AS id").
+		ColumnExpr("owner.name AS name").
+		ColumnExpr("SUM(upload.size) AS bytes").
+		ColumnExpr("COUNT(upload.id) AS objects").
+		Join("JOIN groups AS owner ON owner.id = upload.group_id").
+		Group("upload.group_id", "owner.name").
+		Order("owner.name ASC").
+		Scan(ctx, &records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Cleanup removes all objects below the application prefixes which are not
+// referenced by any record and which are older than the grace period, with
+// dry run enabled the orphaned objects only get reported. The tracking rows
+// of removed objects and of expired objects which never got stored are
+// removed as well, that way they don't count against the quota anymore.
+func (s *Uploads) Cleanup(ctx context.Context, backend upload.Upload, grace time.Duration, dryRun bool) ([]*upload.Object, error) {
+	expired := time.Now().Add(-grace)
+	referenced, stale, err := s.referenced(ctx, expired)
+
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]*upload.Object, 0)
+
+	for _, prefix := range cleanupPrefixes {
+		listed, err := backend.List(ctx, prefix)
+
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, listed...)
+	}
+
+	result := make([]*upload.Object, 0)
+
+	for _, object := range objects {
+		delete(stale, object.Key)
+
+		if _, ok := referenced[object.Key]; ok {
+			continue
+		}
+
+		if object.ModTime.After(expired) {
+			continue
+		}
+
+		if !dryRun {
+			if err := backend.Delete(ctx, object.Key, false); err != nil {
+				return result, err
+			}
+
+			if err := s.untrack(ctx, object.Key, false); err != nil {
+				return result, err
+			}
+		}
+
+		result = append(result, object)
+	}
+
+	if dryRun {
+		return result, nil
+	}
+
+	for key := range stale {
+		if err := s.untrack(ctx, key, false); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// referenced collects the avatars of all users and groups and the tracked
+// uploads which are younger than the grace period, those could still be in
+// progress. Older tracked uploads without any record referencing them are
+// returned as stale, like uploads of deleted owners or aborted uploads.
+func (s *Uploads) referenced(ctx context.Context, expired time.Time) (map[string]struct{}, map[string]struct{}, error) {
+	avatars := make([]string, 0)
+
+	for _, owner := range []any{(*model.User)(nil), (*model.Group)(nil)} {
+		result := make([]string, 0)
+
+		if err := s.client.handle.NewSelect().
+			Model(owner).
+			Column("avatar").
+			Where("avatar != ''").
+			Scan(ctx, &result); err != nil {
+			return nil, nil, err
+		}
+
+		avatars = append(avatars, result...)
+	}
+
+	tracked := make([]*model.Upload, 0)
+
+	if err := s.client.handle.NewSelect().
+		Model(&tracked).
+		Column("path", "updated_at").
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			for _, prefix := range cleanupPrefixes {
+				q = q.WhereOr("path LIKE ?", prefix+"%")
+			}
+
+			return q
+		}).
+		Scan(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	referenced := make(map[string]struct{}, len(avatars)+len(tracked))
+	stale := make(map[string]struct{})
+
+	for _, key := range avatars {
+		referenced[key] = struct{}{}
+	}
+
+	for _, record := range tracked {
+		if _, ok := referenced[record.Path]; ok {
+			continue
+		}
+
+		if record.UpdatedAt.After(expired) {
+			referenced[record.Path] = struct{}{}
+		} else {
+			stale[record.Path] = struct{}{}
+		}
+	}
+
+	return referenced, stale, nil
+}
+
+// reserve accounts an upload to its owner before it gets stored. The owner
+// row gets locked within the transaction, that way concurrent uploads are
+// serialized and can't pass the quota check together. The returned function
+// restores the previous state if storing the upload fails afterwards.
+func (s *Uploads) reserve(ctx context.Context, owner uploadOwner, path string, size int64) (func(), error) {
+	var previous *model.Upload
+
+	if err := s.client.handle.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if err := s.lock(ctx, tx, owner); err != nil {
+			return err
+		}
+
+		if err := s.check(ctx, tx, owner, path, size); err != nil {
+			return err
+		}
+
+		record, err := s.track(ctx, tx, owner, path, size)
+		previous = record
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return func() {
+		_ = s.release(context.WithoutCancel(ctx), path, previous)
+	}, nil
+}
+
+// lock takes a write lock on the owner rows, this works for every supported
+// dialect while SQLite simply serializes all writing transactions. The user
+// always gets locked before the group to avoid deadlocks.
+func (s *Uploads) lock(ctx context.Context, tx bun.Tx, owner uploadOwner) error {
+	if owner.UserID != "" {
+		if _, err := tx.NewUpdate().
+			Table("users").
+			Set("updated_at = updated_at").
+			Where("id = ?", owner.UserID).
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	if owner.GroupID != "" {
+		if _, err := tx.NewUpdate().
+			Table("groups").
+			Set("updated_at = updated_at").
+			Where("id = ?", owner.GroupID).
+			Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Uploads) check(ctx context.Context, tx bun.Tx, owner uploadOwner, path string, size int64) error {
+	if owner.UserID != "" {
+		if err := s.exceeds(ctx, tx, "user_id", owner.UserID, s.quota.User, owner, path, size); err != nil {
+			return err
+		}
+	}
+
+	if owner.GroupID != "" {
+		if err := s.exceeds(ctx, tx, "group_id", owner.GroupID, s.quota.Group, owner, path, size); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Uploads) exceeds(ctx context.Context, tx bun.Tx, column, id string, limit config.QuotaLimit, owner uploadOwner, path string, size int64) error {
+	if limit.Bytes <= 0 && limit.Objects <= 0 {
+		return nil
+	}
+
+	usage := &model.Usage{}
+
+	if err := tx.NewSelect().
+		Model((*model.Upload)(nil)).
+		ColumnExpr("COALESCE(SUM(size), 0) AS bytes").
+		ColumnExpr("COUNT(id) AS objects").
+		Where("? = ?", bun.Ident(column), id).
+		Where("path NOT IN (?)", bun.In([]string{path, owner.Replaces})).
+		Scan(ctx, usage); err != nil {
+		return err
+	}
+
+	if limit.Bytes > 0 && usage.Bytes+size > limit.Bytes {
+		return ErrQuotaExceeded
+	}
+
+	if limit.Objects > 0 && usage.Objects+1 > limit.Objects {
+		return ErrQuotaExceeded
+	}
+
+	return nil
+}
+
+// track records the upload and returns the previously tracked state of the
+// path, which is nil if the path has not been tracked before.
+func (s *Uploads) track(ctx context.Context, tx bun.Tx, owner uploadOwner, path string, size int64) (*model.Upload, error) {
+	record := &model.Upload{}
+
+	if err := tx.NewSelect().
+		Model(record).
+		Where("path = ?", path).
+		Scan(ctx); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	if record.ID == "" {
+		record.Path = path
+		record.UserID = owner.UserID
+		record.GroupID = owner.GroupID
+		record.Size = size
+
+		_, err := tx.NewInsert().
+			Model(record).
+			Exec(ctx)
+
+		return nil, err
+	}
+
+	previous := *record
+	record.UserID = owner.UserID
+	record.GroupID = owner.GroupID
+	record.Size = size
+
+	_, err := tx.NewUpdate().
+		Model(record).
+		WherePK().
+		Exec(ctx)
+
+	return &previous, err
+}
+
+func (s *Uploads) release(ctx context.Context, path string, previous *model.Upload) error {
+	if previous == nil {
+		return s.untrack(ctx, path, false)
+	}
+
+	_, err := s.client.handle.NewUpdate().
+		Model(previous).
+		Column("user_id", "group_id", "size").
+		WherePK().
+		Exec(ctx)
+
+	return err
+}
+
+func (s *Uploads) untrack(ctx context.Context, path string, recursive bool) error {
+	q := s.client.handle.NewDelete().
+		Model((*model.Upload)(nil)).
+		Where("path = ?", path)
+
+	if recursive {
+		q = q.WhereOr("path LIKE ?", strings.TrimSuffix(path, "/")+"/%")
+	}
+
+	_, err := q.Exec(ctx)
+	return err
+}
+
+type uploadOwnerKey struct{}
+
+// uploadOwner defines the user or group an upload gets accounted to, an
+// object which gets replaced by the upload is excluded from the quota.
+type uploadOwner struct {
+	UserID   string
+	GroupID  string
+	Replaces string
+}
+
+func withUploadOwner(ctx context.Context, owner uploadOwner) context.Context {
+	return context.WithValue(ctx, uploadOwnerKey{}, owner)
+}
+
+func uploadOwnerFrom(ctx context.Context) uploadOwner {
+	if owner, ok := ctx.Value(uploadOwnerKey{}).(uploadOwner); ok {
+		return owner
+	}
+
+	return uploadOwner{}
+}
+
+// trackedUpload decorates an upload backend to record every stored object
+// within the database and to enforce the configured quotas.
+type trackedUpload struct {
+	backend upload.Upload
+	uploads *Uploads
+}
+
+// Info implements the upload.Upload interface.
+func (u *trackedUpload) Info() map[string]interface{} {
+	return u.backend.Info()
+}
+
+// Prepare implements the upload.Upload interface.
+func (u *trackedUpload) Prepare() (upload.Upload, error) {
+	if _, err := u.backend.Prepare(); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// Close implements the upload.Upload interface.
+func (u *trackedUpload) Close() error {
+	return u.backend.Close()
+}
+
+// Upload implements the upload.Upload interface.
+func (u *trackedUpload) Upload(ctx context.Context, path string, content io.Reader, size int64, ctype string) error {
+	owner := uploadOwnerFrom(ctx)
+	release, err := u.uploads.reserve(ctx, owner, path, max(size, 0))
+
+	if err != nil {
+		return err
+	}
+
+	if err := u.backend.Upload(ctx, path, content, size, ctype); err != nil {
+		release()
+		return err
+	}
+
+	if size >= 0 {
+		return nil
+	}
+
+	obj, err := u.backend.Stat(ctx, path)
+
+	if err != nil {
+		release()
+		return err
+	}
+
+	if _, err := u.uploads.reserve(ctx, owner, path, obj.Size); err != nil {
+		_ = u.backend.Delete(ctx, path, false)
+		release()
+
+		return err
+	}
+
+	return nil
+}
+
+// Delete implements the upload.Upload interface.
+func (u *trackedUpload) Delete(ctx context.Context, path string, recursive bool) error {
+	if err := u.backend.Delete(ctx, path, recursive); err != nil {
+		return err
+	}
+
+	return u.uploads.untrack(ctx, path, recursive)
+}
+
+// List implements the upload.Upload interface.
+func (u *trackedUpload) List(ctx context.Context, prefix string) ([]*upload.Object, error) {
+	return u.backend.List(ctx, prefix)
+}
+
+// Stat implements the upload.Upload interface.
+func (u *trackedUpload) Stat(ctx context.Context, path string) (*upload.Object, error) {
+	return u.backend.Stat(ctx, path)
+}
+
+// Open implements the upload.Upload interface.
+func (u *trackedUpload) Open(ctx context.Context, path string) (io.ReadCloser, error) {
+	return u.backend.Open(ctx, path)
+}
+
+// Handler implements the upload.Upload interface.
+func (u *trackedUpload) Handler(root string) http.Handler {
+	return u.backend.Handler(root)
+}
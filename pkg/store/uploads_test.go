@@ -0,0 +1,305 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+)
+
+func TestUploadQuota(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing map[string]string
+		replaces string
+		content  string
+		size     int64
+		err      error
+	}{
+		{name: "within limit", content: "four", size: 4},
+		{name: "too many bytes", content: "more than ten", size: 13, err: ErrQuotaExceeded},
+		{name: "unknown size", content: "more than ten", size: -1, err: ErrQuotaExceeded},
+		{name: "too many objects", existing: map[string]string{"a": "a", "b": "b"}, content: "c", size: 1, err: ErrQuotaExceeded},
+		{name: "replaced object", existing: map[string]string{"a": "eight ch"}, replaces: "a", content: "eight ch", size: 8},
+		{name: "filled by others", existing: map[string]string{"a": "eight ch"}, content: "four", size: 4, err: ErrQuotaExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := testUploads(t)
+			s := testStore(t, config.Quota{User: config.QuotaLimit{Bytes: 10, Objects: 2}}, backend)
+			user := testUser(t, s, "jdoe")
+			ctx := withUploadOwner(context.Background(), uploadOwner{UserID: user.ID})
+
+			for key, content := range tt.existing {
+				require.NoError(t, s.upload.Upload(ctx, key, strings.NewReader(content), int64(len(content)), "text/plain"))
+			}
+
+			err := s.upload.Upload(
+				withUploadOwner(context.Background(), uploadOwner{UserID: user.ID, Replaces: tt.replaces}),
+				"upload",
+				strings.NewReader(tt.content),
+				tt.size,
+				"text/plain",
+			)
+
+			tracked, terr := s.Handle().NewSelect().
+				Model((*model.Upload)(nil)).
+				Where("path = ?", "upload").
+				Count(ctx)
+
+			require.NoError(t, terr)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				assert.Equal(t, 0, tracked)
+
+				_, serr := backend.Stat(ctx, "upload")
+				assert.Error(t, serr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, tracked)
+		})
+	}
+}
+
+func TestUploadQuotaConcurrent(t *testing.T) {
+	s := testStore(t, config.Quota{User: config.QuotaLimit{Bytes: 10}}, testUploads(t))
+	user := testUser(t, s, "jdoe")
+	ctx := withUploadOwner(context.Background(), uploadOwner{UserID: user.ID})
+
+	var (
+		wg     sync.WaitGroup
+		mu     sync.Mutex
+		stored int
+	)
+
+	for i := range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			err := s.upload.Upload(ctx, fmt.Sprintf("upload-%d", i), strings.NewReader("four"), 4, "text/plain")
+
+			if err == nil {
+				mu.Lock()
+				stored++
+				mu.Unlock()
+			} else {
+				assert.ErrorIs(t, err, ErrQuotaExceeded)
+			}
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, 2, stored)
+
+	usage, err := s.Uploads.UserUsage(ctx)
+	require.NoError(t, err)
+	require.Len(t, usage, 1)
+	assert.Equal(t, int64(8), usage[0].Bytes)
+}
+
+func TestUploadGroupQuota(t *testing.T) {
+	s := testStore(t, config.Quota{Group: config.QuotaLimit{Bytes: 10}}, testUploads(t))
+	group := testGroup(t, s, "admins")
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		owner   uploadOwner
+		content string
+		err     error
+	}{
+		{name: "group within limit", owner: uploadOwner{GroupID: group.ID}, content: "eight ch"},
+		{name: "user unaffected", owner: uploadOwner{UserID: testUser(t, s, "jdoe").ID}, content: "eight ch"},
+		{name: "group exceeded", owner: uploadOwner{GroupID: group.ID}, content: "four", err: ErrQuotaExceeded},
+		{name: "group replaced", owner: uploadOwner{GroupID: group.ID, Replaces: "group within limit"}, content: "ten chars!"},
+	}
+
+	for _, tt := range tests {
+		err := s.upload.Upload(
+			withUploadOwner(ctx, tt.owner),
+			tt.name,
+			strings.NewReader(tt.content),
+			int64(len(tt.content)),
+			"text/plain",
+		)
+
+		if tt.err != nil {
+			assert.ErrorIs(t, err, tt.err, tt.name)
+		} else {
+			assert.NoError(t, err, tt.name)
+		}
+	}
+
+	usage, err := s.Uploads.GroupUsage(ctx)
+	require.NoError(t, err)
+	require.Len(t, usage, 1)
+	assert.Equal(t, group.ID, usage[0].ID)
+	assert.Equal(t, "admins", usage[0].Name)
+	assert.Equal(t, int64(18), usage[0].Bytes)
+	assert.Equal(t, int64(2), usage[0].Objects)
+}
+
+func TestUploadRelease(t *testing.T) {
+	s := testStore(t, config.Quota{}, nil)
+	user := testUser(t, s, "jdoe")
+	ctx := context.Background()
+	owner := uploadOwner{UserID: user.ID}
+
+	release, err := s.Uploads.reserve(ctx, owner, "upload", 4)
+	require.NoError(t, err)
+	release()
+
+	count, err := s.Handle().NewSelect().
+		Model((*model.Upload)(nil)).
+		Count(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	_, err = s.Uploads.reserve(ctx, owner, "upload", 4)
+	require.NoError(t, err)
+
+	release, err = s.Uploads.reserve(ctx, owner, "upload", 8)
+	require.NoError(t, err)
+	release()
+
+	record := &model.Upload{}
+	require.NoError(t, s.Handle().NewSelect().
+		Model(record).
+		Where("path = ?", "upload").
+		Scan(ctx))
+
+	assert.Equal(t, int64(4), record.Size)
+}
+
+func TestUploadCleanup(t *testing.T) {
+	orphaned := []string{
+		"avatars/orphan.png",
+		"avatars/aborted.png",
+		"avatars/deleted.png",
+	}
+
+	tests := []struct {
+		name    string
+		dryRun  bool
+		removed []string
+		tracked []string
+	}{
+		{
+			name:    "delete",
+			dryRun:  false,
+			removed: orphaned,
+			tracked: []string{"avatars/pending.png"},
+		},
+		{
+			name:    "dry run",
+			dryRun:  true,
+			removed: orphaned,
+			tracked: []string{"avatars/pending.png", "avatars/aborted.png", "avatars/deleted.png", "avatars/missing.png"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			backend := testUploads(t)
+			s := testStore(t, config.Quota{}, backend)
+			user := testUser(t, s, "jdoe")
+			deleted := testUser(t, s, "deleted")
+			expired := time.Now().Add(-2 * time.Hour)
+
+			objects := map[string]time.Time{
+				"avatars/referenced.png": expired,
+				"avatars/pending.png":    expired,
+				"avatars/aborted.png":    expired,
+				"avatars/deleted.png":    expired,
+				"avatars/orphan.png":     expired,
+				"avatars/fresh.png":      time.Now(),
+				"other/unowned.txt":      expired,
+			}
+
+			owners := map[string]string{
+				"avatars/pending.png": user.ID,
+				"avatars/aborted.png": user.ID,
+				"avatars/deleted.png": deleted.ID,
+				"avatars/missing.png": user.ID,
+			}
+
+			for key, modTime := range objects {
+				require.NoError(t, backend.Upload(ctx, key, strings.NewReader("content"), 7, "image/png"))
+
+				require.NoError(t, os.Chtimes(
+					filepath.Join(backend.Info()["path"].(string), key),
+					modTime,
+					modTime,
+				))
+			}
+
+			for key, owner := range owners {
+				require.NoError(t, s.Handle().RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+					_, err := s.Uploads.track(ctx, tx, uploadOwner{UserID: owner}, key, 7)
+					return err
+				}))
+			}
+
+			_, err := s.Handle().NewUpdate().
+				Model((*model.Upload)(nil)).
+				Set("updated_at = ?", expired).
+				Where("path != ?", "avatars/pending.png").
+				Exec(ctx)
+			require.NoError(t, err)
+
+			user.Avatar = "avatars/referenced.png"
+			require.NoError(t, s.Users.Update(ctx, user))
+			require.NoError(t, s.Users.Delete(ctx, deleted.Username))
+
+			removed, err := s.Uploads.Cleanup(ctx, backend, time.Hour, tt.dryRun)
+			require.NoError(t, err)
+
+			keys := make([]string, 0, len(removed))
+
+			for _, object := range removed {
+				keys = append(keys, object.Key)
+			}
+
+			assert.ElementsMatch(t, tt.removed, keys)
+
+			for key := range objects {
+				_, err := backend.Stat(ctx, key)
+
+				if !tt.dryRun && slices.Contains(tt.removed, key) {
+					assert.Error(t, err, key)
+				} else {
+					assert.NoError(t, err, key)
+				}
+			}
+
+			tracked := make([]string, 0)
+
+			require.NoError(t, s.Handle().NewSelect().
+				Model((*model.Upload)(nil)).
+				Column("path").
+				Scan(ctx, &tracked))
+
+			assert.ElementsMatch(t, tt.tracked, tracked)
+		})
+	}
+}
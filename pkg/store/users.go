@@ -1,13 +1,16 @@
 package store
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"path"
 	"strings"
 	"time"
 
+	"github.com/dchest/uniuri"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/gopad/gopad-api/pkg/model"
 	"github.com/gopad/gopad-api/pkg/secret"
@@ -21,53 +24,51 @@ type Users struct {
 }
 
 // List implements the listing of all users.
-func (s *Users) List(ctx context.Context, params model.ListParams) ([]*model.User, int64, error) {
+func (s *Users) List(ctx context.Context, params model.ListParams) ([]*model.User, model.ListMeta, error) {
 	records := make([]*model.User, 0)
 
-	q := s.client.handle.NewSelect().
+	q := s.client.reader().NewSelect().
 		Model(&records).
 		Relation("Auths")
 
-	if val, ok := s.ValidSort(params.Sort); ok {
-		q = q.Order(strings.Join(
-			[]string{
-				val,
-				sortOrder(params.Order),
-			},
-			" ",
-		))
-	}
-
 	if params.Search != "" {
 		q = s.client.SearchQuery(q, params.Search)
 	}
 
-	counter, err := q.Count(ctx)
+	sort, _ := s.ValidSort(params.Sort)
+	meta, err := paginate(
+		ctx,
+		q,
+		&records,
+		params,
+		sort,
+		"user.id",
+		(*model.User)(nil),
+		func(r *model.User) any { return r },
+	)
 
 	if err != nil {
-		return nil, 0, err
-	}
-
-	if params.Limit > 0 {
-		q = q.Limit(int(params.Limit))
-	}
-
-	if params.Offset > 0 {
-		q = q.Offset(int(params.Offset))
-	}
-
-	if err := q.Scan(ctx); err != nil {
-		return nil, int64(counter), err
+		return nil, meta, err
 	}
 
-	return records, int64(counter), nil
+	return records, meta, nil
 }
 
 // Show implements the details for a specific user.
 func (s *Users) Show(ctx context.Context, name string) (*model.User, error) {
+	return s.show(ctx, s.client.reader(), name)
+}
+
+// ShowPrimary implements the details for a specific user from the primary,
+// records which get written back must never be loaded from a replica.
+func (s *Users) ShowPrimary(ctx context.Context, name string) (*model.User, error) {
+	return s.show(ctx, s.client.handle, name)
+}
+
+func (s *Users) show(ctx context.Context, db *bun.DB, name string) (*model.User, error) {
 	record := &model.User{}
 
-	if err := s.client.handle.NewSelect().
+	if err := db.NewSelect().
 		Model(record).
 		Relation("Auths").
 		Where("id = ? OR username = ?", name, name).
@@ -115,7 +116,7 @@ func (s *Users) Update(ctx context.Context, record *model.User) error {
 
 // Delete implements the deletion of an user.
 func (s *Users) Delete(ctx context.Context, name string) error {
-	record, err := s.Show(ctx, name)
+	record, err := s.show(ctx, s.client.handle, name)
 
 	if err != nil {
 		return err
@@ -128,9 +129,85 @@ func (s *Users) Delete(ctx context.Context, name string) error {
 		return err
 	}
 
+	if record.Avatar != "" && s.client.upload != nil {
+		return s.client.upload.Delete(
+			ctx,
+			path.Join("avatars", record.ID),
+			true,
+		)
+	}
+
 	return nil
 }
 
+// SaveAvatar stores a processed avatar and replaces the previous one.
+func (s *Users) SaveAvatar(ctx context.Context, record *model.User, content *bytes.Buffer) error {
+	if s.client.upload == nil {
+		return ErrUploadUnavailable
+	}
+
+	previous := record.Avatar
+	key := path.Join(
+		"avatars",
+		record.ID,
+		strings.ToLower(uniuri.NewLen(8))+".png",
+	)
+
+	if err := s.client.upload.Upload(
+		withUploadOwner(ctx, uploadOwner{UserID: record.ID, Replaces: previous}),
+		key,
+		content,
+		int64(content.Len()),
+		"image/png",
+	); err != nil {
+		return err
+	}
+
+	record.Avatar = key
+
+	if _, err := s.client.handle.NewUpdate().
+		Model(record).
+		Column("avatar", "updated_at").
+		Where("id = ?", record.ID).
+		Exec(ctx); err != nil {
+		record.Avatar = previous
+		_ = s.client.upload.Delete(ctx, key, false)
+
+		return err
+	}
+
+	if previous != "" {
+		_ = s.client.upload.Delete(ctx, previous, false)
+	}
+
+	return nil
+}
+
+// DeleteAvatar removes the uploaded avatar of an user.
+func (s *Users) DeleteAvatar(ctx context.Context, record *model.User) error {
+	if record.Avatar == "" {
+		return nil
+	}
+
+	if s.client.upload == nil {
+		return ErrUploadUnavailable
+	}
+
+	previous := record.Avatar
+	record.Avatar = ""
+
+	if _, err := s.client.handle.NewUpdate().
+		Model(record).
+		Column("avatar", "updated_at").
+		Where("id = ?", record.ID).
+		Exec(ctx); err != nil {
+		record.Avatar = previous
+		return err
+	}
+
+	return s.client.upload.Delete(ctx, previous, false)
+}
+
 // ShowRedirectToken implements the details for a specific redirect token.
 func (s *Users) ShowRedirectToken(ctx context.Context, token string) (*model.UserToken, error) {
 	record := &model.UserToken{}
@@ -152,7 +229,7 @@ func (s *Users) ShowRedirectToken(ctx context.Context, token string) (*model.Use
 
 // CreateRedirectToken implements the create of a new redirect token.
 func (s *Users) CreateRedirectToken(ctx context.Context, username string) (*model.UserToken, error) {
-	user, err := s.Show(ctx, username)
+	user, err := s.show(ctx, s.client.handle, username)
 
 	if err != nil {
 		return nil, err
@@ -200,59 +277,47 @@ func (s *Users) CleanupRedirectTokens(ctx context.Context) error {
 }
 
 // ListGroups implements the listing of all groups for an user.
-func (s *Users) ListGroups(ctx context.Context, params model.UserGroupParams) ([]*model.UserGroup, int64, error) {
+func (s *Users) ListGroups(ctx context.Context, params model.UserGroupParams) ([]*model.UserGroup, model.ListMeta, error) {
 	records := make([]*model.UserGroup, 0)
 
-	q := s.client.handle.NewSelect().
+	q := s.client.reader().NewSelect().
 		Model(&records).
 		Relation("User").
 		Relation("Group").
 		Where("user_id = ?", params.UserID)
 
-	if val, ok := s.client.Groups.ValidSort(params.Sort); ok {
-		q = q.Order(strings.Join(
-			[]string{
-				val,
-				sortOrder(params.Order),
-			},
-			" ",
-		))
-	}
-
 	if params.Search != "" {
 		q = s.client.SearchQuery(q, params.Search)
 	}
 
-	counter, err := q.Count(ctx)
+	sort, _ := s.client.Groups.ValidSort(params.Sort)
+	meta, err := paginate(
+		ctx,
+		q,
+		&records,
+		params.ListParams,
+		sort,
+		"group.id",
+		(*model.Group)(nil),
+		func(r *model.UserGroup) any { return r.Group },
+	)
 
 	if err != nil {
-		return nil, 0, err
+		return nil, meta, err
 	}
 
-	if params.Limit > 0 {
-		q = q.Limit(int(params.Limit))
-	}
-
-	if params.Offset > 0 {
-		q = q.Offset(int(params.Offset))
-	}
-
-	if err := q.Scan(ctx); err != nil {
-		return nil, int64(counter), err
-	}
-
-	return records, int64(counter), nil
+	return records, meta, nil
 }
 
 // AttachGroup implements the attachment of an user to a group.
 func (s *Users) AttachGroup(ctx context.Context, params model.UserGroupParams) error {
-	user, err := s.Show(ctx, params.UserID)
+	user, err := s.show(ctx, s.client.handle, params.UserID)
 
 	if err != nil {
 		return err
 	}
 
-	group, err := s.client.Groups.Show(ctx, params.GroupID)
+	group, err := s.client.Groups.show(ctx, s.client.handle, params.GroupID)
 
 	if err != nil {
 		return err
@@ -278,24 +343,26 @@ func (s *Users) AttachGroup(ctx context.Context, params model.UserGroupParams) e
 		return err
 	}
 
-	if _, err := s.client.handle.NewInsert().
-		Model(record).
-		Exec(ctx); err != nil {
-		return err
-	}
+	return s.client.handle.RunInTx(ctx, &sql.TxOptions{}, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewInsert().
+			Model(record).
+			Exec(ctx); err != nil {
+			return err
+		}
 
-	return nil
+		return s.client.Notifications.groupAttached(ctx, tx, user, group, record.Perm)
+	})
 }
 
 // PermitGroup implements the permission update for a group on an user.
 func (s *Users) PermitGroup(ctx context.Context, params model.UserGroupParams) error {
-	user, err := s.Show(ctx, params.UserID)
+	user, err := s.show(ctx, s.client.handle, params.UserID)
 
 	if err != nil {
 		return err
 	}
 
-	group, err := s.client.Groups.Show(ctx, params.GroupID)
+	group, err := s.client.Groups.show(ctx, s.client.handle, params.GroupID)
 
 	if err != nil {
 		return err
@@ -311,6 +378,10 @@ func (s *Users) PermitGroup(ctx context.Context, params model.UserGroupParams) e
 		return ErrNotAssigned
 	}
 
+	if err := s.validatePerm(params.Perm); err != nil {
+		return err
+	}
+
 	if _, err := s.client.handle.NewUpdate().
 		Model((*model.UserGroup)(nil)).
 		Set("perm = ?", params.Perm).
@@ -324,13 +395,13 @@ func (s *Users) PermitGroup(ctx context.Context, params model.UserGroupParams) e
 
 // DropGroup implements the removal of an user from a group.
 func (s *Users) DropGroup(ctx context.Context, params model.UserGroupParams) error {
-	user, err := s.Show(ctx, params.UserID)
+	user, err := s.show(ctx, s.client.handle, params.UserID)
 
 	if err != nil {
 		return err
 	}
 
-	group, err := s.client.Groups.Show(ctx, params.GroupID)
+	group, err := s.client.Groups.show(ctx, s.client.handle, params.GroupID)
 
 	if err != nil {
 		return err
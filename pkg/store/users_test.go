@@ -0,0 +1,56 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/gopad/gopad-api/pkg/model"
+	"github.com/gopad/gopad-api/pkg/validate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermitValidatesPerm(t *testing.T) {
+	s := testStore(t, config.Quota{}, nil)
+	ctx := context.Background()
+	user := testUser(t, s, "jdoe")
+	group := testGroup(t, s, "team")
+
+	require.NoError(t, s.Users.AttachGroup(ctx, model.UserGroupParams{
+		UserID:  user.ID,
+		GroupID: group.ID,
+		Perm:    model.UserGroupUserPerm,
+	}))
+
+	tests := []struct {
+		name  string
+		perm  string
+		valid bool
+	}{
+		{name: "user", perm: model.UserGroupUserPerm, valid: true},
+		{name: "admin", perm: model.UserGroupAdminPerm, valid: true},
+		{name: "unknown", perm: "superuser", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := model.UserGroupParams{
+				UserID:  user.ID,
+				GroupID: group.ID,
+				Perm:    tt.perm,
+			}
+
+			for _, err := range []error{
+				s.Users.PermitGroup(ctx, params),
+				s.Groups.PermitUser(ctx, params),
+			} {
+				if tt.valid {
+					assert.NoError(t, err)
+				} else {
+					assert.IsType(t, validate.Errors{}, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,406 @@
+package upload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/gopad/gopad-api/pkg/config"
+)
+
+// AzureUpload implements the Upload interface.
+type AzureUpload struct {
+	endpoint string
+	path     string
+	access   string
+	secret   string
+	bucket   string
+	proxy    bool
+
+	client *container.Client
+}
+
+// Info prepares some informational message about the handler.
+func (u *AzureUpload) Info() map[string]interface{} {
+	result := make(map[string]interface{})
+	result["driver"] = "azure"
+	result["endpoint"] = u.endpoint
+	result["path"] = u.path
+	result["bucket"] = u.bucket
+	result["proxy"] = u.proxy
+
+	return result
+}
+
+// Prepare simply prepares the upload handler.
+func (u *AzureUpload) Prepare() (Upload, error) {
+	if u.access == "" || u.secret == "" {
+		return nil, fmt.Errorf("missing account name or key")
+	}
+
+	access, err := config.Value(u.access)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse access key: %w", err)
+	}
+
+	secret, err := config.Value(u.secret)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse secret key: %w", err)
+	}
+
+	cred, err := container.NewSharedKeyCredential(
+		access,
+		secret,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse credentials: %w", err)
+	}
+
+	endpoint := u.endpoint
+
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net/", access)
+	}
+
+	client, err := container.NewClientWithSharedKeyCredential(
+		strings.TrimSuffix(endpoint, "/")+"/"+u.bucket,
+		cred,
+		nil,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	u.client = client
+	return u, nil
+}
+
+// Close simply closes the upload handler.
+func (u *AzureUpload) Close() error {
+	return nil
+}
+
+// Upload streams an attachment into the defined Azure container.
+func (u *AzureUpload) Upload(ctx context.Context, key string, content io.Reader, size int64, ctype string) error {
+	if ctype == "" {
+		detected, reader, err := sniffUpload(content)
+
+		if err != nil {
+			return err
+		}
+
+		ctype = detected
+		content = reader
+	}
+
+	client := u.client.NewBlockBlobClient(
+		path.Join(u.path, key),
+	)
+
+	// The block list only gets committed after the content has been read
+	// completely, a failing read leaves just uncommitted blocks behind.
+	_, err := client.UploadStream(
+		ctx,
+		&sizeReader{
+			reader: content,
+			size:   size,
+		},
+		&blockblob.UploadStreamOptions{
+			HTTPHeaders: &blob.HTTPHeaders{
+				BlobContentType: to.Ptr(ctype),
+			},
+		},
+	)
+
+	return err
+}
+
+// Delete removes an attachment from the defined Azure container.
+func (u *AzureUpload) Delete(ctx context.Context, key string, recursive bool) error {
+	if recursive {
+		objects, err := u.List(ctx, key)
+
+		if err != nil {
+			return err
+		}
+
+		for _, object := range objects {
+			if _, err := u.client.NewBlobClient(
+				path.Join(u.path, object.Key),
+			).Delete(
+				ctx,
+				nil,
+			); err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+				return fmt.Errorf("failed to delete objects: %w", err)
+			}
+		}
+
+		return nil
+	}
+
+	if _, err := u.client.NewBlobClient(
+		path.Join(u.path, key),
+	).Delete(
+		ctx,
+		nil,
+	); err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return err
+	}
+
+	return nil
+}
+
+// List returns all attachments stored below the defined prefix.
+func (u *AzureUpload) List(ctx context.Context, prefix string) ([]*Object, error) {
+	result := make([]*Object, 0)
+
+	pager := u.client.NewListBlobsFlatPager(
+		&container.ListBlobsFlatOptions{
+			Prefix: to.Ptr(strings.TrimPrefix(path.Join(u.path, prefix), "/")),
+		},
+	)
+
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		for _, object := range page.Segment.BlobItems {
+			result = append(result, &Object{
+				Key: strings.TrimPrefix(
+					strings.TrimPrefix(
+						deref(object.Name),
+						u.path,
+					),
+					"/",
+				),
+				Size:        deref(object.Properties.ContentLength),
+				ModTime:     deref(object.Properties.LastModified),
+				ContentType: deref(object.Properties.ContentType),
+				ETag:        string(deref(object.Properties.ETag)),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// Stat returns the metadata of an attachment from the defined Azure container.
+func (u *AzureUpload) Stat(ctx context.Context, key string) (*Object, error) {
+	props, err := u.client.NewBlobClient(
+		path.Join(u.path, key),
+	).GetProperties(
+		ctx,
+		nil,
+	)
+
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &Object{
+		Key:         key,
+		Size:        deref(props.ContentLength),
+		ModTime:     deref(props.LastModified),
+		ContentType: deref(props.ContentType),
+		ETag:        string(deref(props.ETag)),
+	}, nil
+}
+
+// Open provides a reader for an attachment from the defined Azure container.
+func (u *AzureUpload) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := u.client.NewBlobClient(
+		path.Join(u.path, key),
+	).DownloadStream(
+		ctx,
+		nil,
+	)
+
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// OpenRange provides a reader for a range of an attachment from the defined Azure container.
+func (u *AzureUpload) OpenRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	resp, err := u.client.NewBlobClient(
+		path.Join(u.path, key),
+	).DownloadStream(
+		ctx,
+		&blob.DownloadStreamOptions{
+			Range: blob.HTTPRange{
+				Offset: offset,
+				Count:  max(length, 0),
+			},
+		},
+	)
+
+	if err != nil {
+		if bloberror.HasCode(err, bloberror.BlobNotFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+// Handler implements an HTTP handler for asset uploads.
+func (u *AzureUpload) Handler(root string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u.proxy {
+			u.proxyHandler(root, w, r)
+		} else {
+			u.presignHandler(root, w, r)
+		}
+	})
+}
+
+func (u *AzureUpload) proxyHandler(root string, w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(
+		path.Join(
+			"/",
+			strings.TrimPrefix(
+				r.URL.Path,
+				root,
+			),
+		),
+		"/",
+	)
+
+	obj, err := u.Stat(
+		r.Context(),
+		key,
+	)
+
+	if err != nil {
+		http.Error(
+			w,
+			http.StatusText(http.StatusNotFound),
+			http.StatusNotFound,
+		)
+
+		return
+	}
+
+	if obj.ContentType != "" {
+		w.Header().Set("Content-Type", obj.ContentType)
+	}
+
+	if obj.ETag != "" {
+		w.Header().Set("ETag", obj.ETag)
+	}
+
+	reader := &rangeReader{
+		ctx:  r.Context(),
+		size: obj.Size,
+		open: func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+			return u.OpenRange(ctx, key, offset, -1)
+		},
+	}
+
+	defer func() { _ = reader.Close() }()
+
+	http.ServeContent(
+		w,
+		r,
+		key,
+		obj.ModTime,
+		reader,
+	)
+}
+
+func (u *AzureUpload) presignHandler(root string, w http.ResponseWriter, r *http.Request) {
+	obj := strings.TrimPrefix(
+		path.Join(
+			u.path,
+			strings.TrimPrefix(
+				r.URL.Path,
+				root,
+			),
+		),
+		"/",
+	)
+
+	signed, err := u.client.NewBlobClient(obj).GetSASURL(
+		sas.BlobPermissions{
+			Read: true,
+		},
+		time.Now().UTC().Add(5*time.Minute),
+		nil,
+	)
+
+	if err != nil {
+		http.Error(
+			w,
+			http.StatusText(http.StatusNotFound),
+			http.StatusNotFound,
+		)
+
+		return
+	}
+
+	http.Redirect(w, r, signed, http.StatusTemporaryRedirect)
+}
+
+// NewAzureUpload initializes a new Azure Blob handler.
+func NewAzureUpload(cfg config.Upload) (Upload, error) {
+	f := &AzureUpload{
+		endpoint: cfg.Endpoint,
+		path:     cfg.Path,
+		access:   cfg.Access,
+		secret:   cfg.Secret,
+		bucket:   cfg.Bucket,
+		proxy:    cfg.Proxy,
+	}
+
+	return f.Prepare()
+}
+
+// MustAzureUpload simply calls NewAzureUpload and panics on an error.
+func MustAzureUpload(cfg config.Upload) Upload {
+	db, err := NewAzureUpload(cfg)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return db
+}
+
+func deref[T any](val *T) T {
+	var result T
+
+	if val != nil {
+		result = *val
+	}
+
+	return result
+}
@@ -0,0 +1,41 @@
+package upload
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+// azuriteKey defines the well-known account key of the Azurite emulator.
+const azuriteKey = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+
+func TestAzureUpload(t *testing.T) {
+	endpoint := os.Getenv("GOPAD_API_TEST_AZURE_ENDPOINT")
+
+	if endpoint == "" {
+		t.Skip("GOPAD_API_TEST_AZURE_ENDPOINT is not defined, e.g. http://127.0.0.1:10000/devstoreaccount1")
+	}
+
+	backend, err := NewAzureUpload(config.Upload{
+		Endpoint: endpoint,
+		Bucket:   "gopad",
+		Path:     "tests",
+		Access:   "devstoreaccount1",
+		Secret:   azuriteKey,
+	})
+
+	require.NoError(t, err)
+
+	if _, err := backend.(*AzureUpload).client.Create(
+		context.Background(),
+		nil,
+	); err != nil && !bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+		require.NoError(t, err)
+	}
+
+	testDriver(t, backend)
+}
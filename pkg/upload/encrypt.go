@@ -0,0 +1,832 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gopad/gopad-api/pkg/config"
+)
+
+const (
+	encryptMagic  = "GPE1"
+	encryptKeyID  = 16
+	encryptDEK    = 32
+	encryptNonce  = 12
+	encryptPrefix = 7
+	encryptChunk  = 64 * 1024
+	encryptTag    = 16
+
+	// encryptHeader is the length of the magic, the padded key ID, the
+	// wrapped data key including its nonce and the chunk nonce prefix.
+	encryptHeader = len(encryptMagic) + encryptKeyID + encryptNonce + encryptDEK + encryptTag + encryptPrefix
+)
+
+var (
+	// ErrUnknownKey defines a named error for objects with an unknown key ID.
+	ErrUnknownKey = fmt.Errorf("unknown encryption key")
+
+	// ErrCorrupted defines a named error for objects which can't be decrypted.
+	ErrCorrupted = fmt.Errorf("corrupted encrypted upload")
+
+	// ErrUnencrypted defines a named error for objects stored without encryption.
+	ErrUnencrypted = fmt.Errorf("unencrypted upload")
+)
+
+// EncryptedUpload implements the Upload interface by wrapping another driver
+// with envelope encryption. Every object gets encrypted by a random data key
+// which is wrapped by the current master key and stored within the header.
+type EncryptedUpload struct {
+	backend   Upload
+	current   string
+	keys      map[string]cipher.AEAD
+	plaintext bool
+}
+
+// Info prepares some informational message about the handler.
+func (u *EncryptedUpload) Info() map[string]interface{} {
+	result := u.backend.Info()
+	result["encryption"] = u.current
+
+	return result
+}
+
+// Prepare simply prepares the upload handler.
+func (u *EncryptedUpload) Prepare() (Upload, error) {
+	if _, err := u.backend.Prepare(); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// Close simply closes the upload handler.
+func (u *EncryptedUpload) Close() error {
+	return u.backend.Close()
+}
+
+// Upload encrypts and streams an attachment into the wrapped driver.
+func (u *EncryptedUpload) Upload(ctx context.Context, key string, content io.Reader, size int64, _ string) error {
+	reader, err := u.encrypt(content)
+
+	if err != nil {
+		return err
+	}
+
+	if size >= 0 {
+		size = encryptedSize(size)
+	}
+
+	return u.backend.Upload(
+		ctx,
+		key,
+		reader,
+		size,
+		"application/octet-stream",
+	)
+}
+
+// Delete removes an attachment from the wrapped driver.
+func (u *EncryptedUpload) Delete(ctx context.Context, key string, recursive bool) error {
+	return u.backend.Delete(ctx, key, recursive)
+}
+
+// List returns all attachments stored below the defined prefix.
+func (u *EncryptedUpload) List(ctx context.Context, prefix string) ([]*Object, error) {
+	objects, err := u.backend.List(ctx, prefix)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, object := range objects {
+		if err := u.resolve(ctx, object); err != nil {
+			return nil, err
+		}
+	}
+
+	return objects, nil
+}
+
+// Stat returns the metadata of an attachment with its decrypted size.
+func (u *EncryptedUpload) Stat(ctx context.Context, key string) (*Object, error) {
+	object, err := u.backend.Stat(ctx, key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.resolve(ctx, object); err != nil {
+		return nil, err
+	}
+
+	object.ContentType = mime.TypeByExtension(filepath.Ext(key))
+	return object, nil
+}
+
+// Open provides a decrypting reader for an attachment.
+func (u *EncryptedUpload) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	return u.open(ctx, key, u.plaintext)
+}
+
+// Handler implements an HTTP handler which decrypts the served attachments.
+func (u *EncryptedUpload) Handler(root string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(
+			path.Join(
+				"/",
+				strings.TrimPrefix(
+					r.URL.Path,
+					root,
+				),
+			),
+			"/",
+		)
+
+		obj, err := u.Stat(
+			r.Context(),
+			key,
+		)
+
+		if err != nil {
+			http.Error(
+				w,
+				http.StatusText(http.StatusNotFound),
+				http.StatusNotFound,
+			)
+
+			return
+		}
+
+		if obj.ContentType != "" {
+			w.Header().Set("Content-Type", obj.ContentType)
+		}
+
+		if obj.ETag != "" {
+			w.Header().Set("ETag", obj.ETag)
+		}
+
+		reader := &decryptSeeker{
+			ctx:    r.Context(),
+			upload: u,
+			key:    key,
+			size:   obj.Size,
+		}
+
+		defer func() { _ = reader.Close() }()
+
+		http.ServeContent(
+			w,
+			r,
+			key,
+			obj.ModTime,
+			reader,
+		)
+	})
+}
+
+// Reencrypt rewrites an attachment with the current master key if it is not
+// encrypted yet or if it had been encrypted with a previous key.
+func (u *EncryptedUpload) Reencrypt(ctx context.Context, key string, dryRun bool) (bool, error) {
+	keyID, err := u.inspect(ctx, key)
+
+	if err != nil {
+		return false, err
+	}
+
+	if keyID == u.current {
+		return false, nil
+	}
+
+	if dryRun {
+		return true, nil
+	}
+
+	reader, err := u.open(ctx, key, true)
+
+	if err != nil {
+		return false, err
+	}
+
+	spooled, err := spoolUpload(reader)
+	_ = reader.Close()
+
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		_ = spooled.Close()
+		_ = os.Remove(spooled.Name())
+	}()
+
+	info, err := spooled.Stat()
+
+	if err != nil {
+		return false, err
+	}
+
+	if err := u.Upload(ctx, key, spooled, info.Size(), ""); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// resolve calculates the plaintext size of an attachment from the stored
+// size. Only with plaintext enabled the header has to be inspected, since
+// the attachment could have been stored before encryption got enabled.
+func (u *EncryptedUpload) resolve(ctx context.Context, object *Object) error {
+	if !u.plaintext {
+		object.Size = plainSize(object.Size)
+		return nil
+	}
+
+	keyID, err := u.inspect(ctx, object.Key)
+
+	if err != nil {
+		return err
+	}
+
+	if keyID != "" {
+		object.Size = plainSize(object.Size)
+	}
+
+	return nil
+}
+
+func (u *EncryptedUpload) open(ctx context.Context, key string, plaintext bool) (io.ReadCloser, error) {
+	source, err := u.backend.Open(ctx, key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptReader{
+		source:    source,
+		keys:      u.keys,
+		plaintext: plaintext,
+	}, nil
+}
+
+// openAt provides a decrypting reader starting at a plaintext offset. Chunks
+// have a fixed size, so only the chunk containing the offset and the chunks
+// after it are read and decrypted.
+func (u *EncryptedUpload) openAt(ctx context.Context, key string, offset int64) (io.ReadCloser, error) {
+	if offset == 0 {
+		return u.open(ctx, key, u.plaintext)
+	}
+
+	ranger, ok := u.backend.(RangeUpload)
+
+	if !ok {
+		ranger = &skipRange{backend: u.backend}
+	}
+
+	head, err := ranger.OpenRange(ctx, key, 0, int64(encryptHeader))
+
+	if err != nil {
+		return nil, err
+	}
+
+	reader := &decryptReader{
+		source:    head,
+		keys:      u.keys,
+		plaintext: u.plaintext,
+		started:   true,
+	}
+
+	err = reader.header()
+	_ = head.Close()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if reader.plain != nil {
+		source, err := ranger.OpenRange(ctx, key, offset, -1)
+
+		if err != nil {
+			return nil, err
+		}
+
+		reader.source = source
+		reader.plain = source
+
+		return reader, nil
+	}
+
+	index := offset / encryptChunk
+	source, err := ranger.OpenRange(ctx, key, int64(encryptHeader)+index*(encryptChunk+encryptTag), -1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	reader.source = source
+	reader.counter = uint32(index)
+
+	if _, err := io.CopyN(io.Discard, reader, offset%encryptChunk); err != nil {
+		_ = reader.Close()
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+// inspect returns the key ID of an encrypted attachment, or an empty string
+// for attachments which have been stored before encryption got enabled.
+func (u *EncryptedUpload) inspect(ctx context.Context, key string) (string, error) {
+	reader, err := u.backend.Open(ctx, key)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer func() { _ = reader.Close() }()
+
+	header := make([]byte, len(encryptMagic)+encryptKeyID)
+
+	if _, err := io.ReadFull(reader, header); err != nil {
+		return "", nil
+	}
+
+	if string(header[:len(encryptMagic)]) != encryptMagic {
+		return "", nil
+	}
+
+	return string(bytes.TrimRight(header[len(encryptMagic):], "\x00")), nil
+}
+
+func (u *EncryptedUpload) encrypt(content io.Reader) (io.Reader, error) {
+	dek := make([]byte, encryptDEK)
+
+	if _, err := rand.Read(dek); err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(dek)
+
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, encryptHeader)
+	header = append(header, encryptMagic...)
+	header = append(header, padKeyID(u.current)...)
+
+	nonce := make([]byte, encryptNonce)
+
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	header = append(header, nonce...)
+	header = u.keys[u.current].Seal(header, nonce, dek, nil)
+
+	prefix := make([]byte, encryptPrefix)
+
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, err
+	}
+
+	header = append(header, prefix...)
+
+	return &encryptReader{
+		source: content,
+		aead:   aead,
+		prefix: prefix,
+		out:    header,
+	}, nil
+}
+
+// encryptReader encrypts the source in chunks, every chunk nonce contains a
+// counter and a flag for the last chunk to detect reordering and truncation.
+type encryptReader struct {
+	source  io.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	out     []byte
+	chunk   []byte
+	eof     bool
+	started bool
+	done    bool
+}
+
+// Read implements the io.Reader interface.
+func (r *encryptReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+
+		if err := r.fill(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+
+	return n, nil
+}
+
+func (r *encryptReader) fill() error {
+	if !r.started {
+		chunk, eof, err := readChunk(r.source)
+
+		if err != nil {
+			return err
+		}
+
+		r.chunk, r.eof, r.started = chunk, eof, true
+	}
+
+	var (
+		next []byte
+		eof  bool
+		last = r.eof
+	)
+
+	if !last {
+		chunk, nextEOF, err := readChunk(r.source)
+
+		if err != nil {
+			return err
+		}
+
+		next, eof = chunk, nextEOF
+		last = len(next) == 0
+	}
+
+	r.out = r.aead.Seal(nil, chunkNonce(r.prefix, r.counter, last), r.chunk, nil)
+	r.counter++
+
+	if last {
+		r.done = true
+	} else {
+		r.chunk, r.eof = next, eof
+	}
+
+	return nil
+}
+
+// decryptReader decrypts a source written by the encryptReader, sources
+// without the expected magic are only passed through with plaintext enabled.
+type decryptReader struct {
+	source    io.ReadCloser
+	keys      map[string]cipher.AEAD
+	plaintext bool
+	aead      cipher.AEAD
+	prefix    []byte
+	counter   uint32
+	plain     io.Reader
+	out       []byte
+	started   bool
+	done      bool
+}
+
+// Read implements the io.Reader interface.
+func (r *decryptReader) Read(p []byte) (int, error) {
+	if !r.started {
+		if err := r.header(); err != nil {
+			return 0, err
+		}
+
+		r.started = true
+	}
+
+	if r.plain != nil {
+		return r.plain.Read(p)
+	}
+
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+
+		if err := r.fill(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+
+	return n, nil
+}
+
+// Close implements the io.Closer interface.
+func (r *decryptReader) Close() error {
+	return r.source.Close()
+}
+
+func (r *decryptReader) header() error {
+	header := make([]byte, encryptHeader)
+	n, err := io.ReadFull(r.source, header)
+
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return err
+	}
+
+	if n < encryptHeader || string(header[:len(encryptMagic)]) != encryptMagic {
+		if !r.plaintext {
+			return ErrUnencrypted
+		}
+
+		r.plain = io.MultiReader(bytes.NewReader(header[:n]), r.source)
+		return nil
+	}
+
+	offset := len(encryptMagic)
+	keyID := string(bytes.TrimRight(header[offset:offset+encryptKeyID], "\x00"))
+	offset += encryptKeyID
+
+	master, ok := r.keys[keyID]
+
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+
+	nonce := header[offset : offset+encryptNonce]
+	offset += encryptNonce
+
+	dek, err := master.Open(nil, nonce, header[offset:offset+encryptDEK+encryptTag], nil)
+
+	if err != nil {
+		return ErrCorrupted
+	}
+
+	offset += encryptDEK + encryptTag
+
+	aead, err := newAEAD(dek)
+
+	if err != nil {
+		return err
+	}
+
+	r.aead = aead
+	r.prefix = header[offset : offset+encryptPrefix]
+
+	return nil
+}
+
+func (r *decryptReader) fill() error {
+	sealed := make([]byte, encryptChunk+encryptTag)
+	n, err := io.ReadFull(r.source, sealed)
+
+	switch {
+	case err == io.EOF:
+		return ErrCorrupted
+	case err == io.ErrUnexpectedEOF:
+	case err != nil:
+		return err
+	}
+
+	sealed = sealed[:n]
+
+	if n == encryptChunk+encryptTag {
+		if plain, err := r.aead.Open(nil, chunkNonce(r.prefix, r.counter, false), sealed, nil); err == nil {
+			r.out = plain
+			r.counter++
+
+			return nil
+		}
+	}
+
+	plain, err := r.aead.Open(nil, chunkNonce(r.prefix, r.counter, true), sealed, nil)
+
+	if err != nil {
+		return ErrCorrupted
+	}
+
+	r.out = plain
+	r.done = true
+
+	return nil
+}
+
+// decryptSeeker provides seeking on top of decrypted attachments for
+// http.ServeContent, seeking reopens the attachment at the chunk containing
+// the offset.
+type decryptSeeker struct {
+	ctx      context.Context
+	upload   *EncryptedUpload
+	key      string
+	size     int64
+	offset   int64
+	position int64
+	reader   io.ReadCloser
+}
+
+// Read implements the io.Reader interface.
+func (r *decryptSeeker) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.reader == nil || r.position != r.offset {
+		if err := r.Close(); err != nil {
+			return 0, err
+		}
+
+		reader, err := r.upload.openAt(r.ctx, r.key, r.offset)
+
+		if err != nil {
+			return 0, err
+		}
+
+		r.reader = reader
+		r.position = r.offset
+	}
+
+	n, err := r.reader.Read(p)
+	r.offset += int64(n)
+	r.position += int64(n)
+
+	return n, err
+}
+
+// Seek implements the io.Seeker interface.
+func (r *decryptSeeker) Seek(offset int64, whence int) (int64, error) {
+	target := offset
+
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		target += r.offset
+	case io.SeekEnd:
+		target += r.size
+	default:
+		return 0, fmt.Errorf("invalid seek whence")
+	}
+
+	if target < 0 {
+		return 0, fmt.Errorf("negative seek position")
+	}
+
+	r.offset = target
+	return target, nil
+}
+
+// Close implements the io.Closer interface.
+func (r *decryptSeeker) Close() error {
+	if r.reader == nil {
+		return nil
+	}
+
+	err := r.reader.Close()
+	r.reader = nil
+
+	return err
+}
+
+// skipRange opens ranges for drivers without range support by skipping the
+// stored bytes up to the offset, at least nothing gets decrypted for that.
+type skipRange struct {
+	backend Upload
+}
+
+// OpenRange implements the RangeUpload interface.
+func (r *skipRange) OpenRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	body, err := r.backend.Open(ctx, key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.CopyN(io.Discard, body, offset); err != nil && err != io.EOF {
+		_ = body.Close()
+		return nil, err
+	}
+
+	return limitRange(body, length), nil
+}
+
+func readChunk(r io.Reader) ([]byte, bool, error) {
+	chunk := make([]byte, encryptChunk)
+	n, err := io.ReadFull(r, chunk)
+
+	switch {
+	case err == io.EOF:
+		return chunk[:0], true, nil
+	case err == io.ErrUnexpectedEOF:
+		return chunk[:n], true, nil
+	case err != nil:
+		return nil, false, err
+	}
+
+	return chunk, false, nil
+}
+
+func chunkNonce(prefix []byte, counter uint32, last bool) []byte {
+	nonce := make([]byte, encryptNonce)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[encryptPrefix:], counter)
+
+	if last {
+		nonce[encryptNonce-1] = 1
+	}
+
+	return nonce
+}
+
+func padKeyID(keyID string) []byte {
+	result := make([]byte, encryptKeyID)
+	copy(result, keyID)
+
+	return result
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptedSize calculates the stored size for a plaintext size.
+func encryptedSize(size int64) int64 {
+	chunks := max((size+encryptChunk-1)/encryptChunk, 1)
+	return int64(encryptHeader) + size + chunks*encryptTag
+}
+
+// plainSize calculates the plaintext size for a stored size.
+func plainSize(size int64) int64 {
+	body := size - int64(encryptHeader)
+	chunks := max((body+encryptChunk+encryptTag-1)/(encryptChunk+encryptTag), 1)
+
+	return max(body-chunks*encryptTag, 0)
+}
+
+// NewEncryptedUpload wraps an upload driver with envelope encryption. Keys
+// are defined as id:value, the value gets resolved by config.Value and must
+// contain 32 bytes. The first key encrypts, all keys are used to decrypt.
+// Plaintext serves attachments stored before encryption got enabled, it is
+// meant to be used until all attachments got reencrypted.
+func NewEncryptedUpload(backend Upload, keys []string, plaintext bool) (Upload, error) {
+	u := &EncryptedUpload{
+		backend:   backend,
+		keys:      make(map[string]cipher.AEAD, len(keys)),
+		plaintext: plaintext,
+	}
+
+	for _, val := range keys {
+		keyID, secret, ok := strings.Cut(strings.TrimSpace(val), ":")
+
+		if !ok || keyID == "" || len(keyID) > encryptKeyID {
+			return nil, fmt.Errorf("invalid encryption key id, expected up to %d chars", encryptKeyID)
+		}
+
+		content, err := config.Value(secret)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse encryption key %s: %w", keyID, err)
+		}
+
+		if len(content) != encryptDEK {
+			content = strings.TrimSpace(content)
+		}
+
+		if len(content) != encryptDEK {
+			return nil, fmt.Errorf("encryption key %s must be %d bytes", keyID, encryptDEK)
+		}
+
+		aead, err := newAEAD([]byte(content))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := u.keys[keyID]; ok {
+			return nil, fmt.Errorf("duplicate encryption key %s", keyID)
+		}
+
+		if u.current == "" {
+			u.current = keyID
+		}
+
+		u.keys[keyID] = aead
+	}
+
+	if u.current == "" {
+		return nil, fmt.Errorf("missing encryption key")
+	}
+
+	return u, nil
+}
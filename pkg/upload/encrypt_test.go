@@ -0,0 +1,359 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+	}{
+		{name: "empty", size: 0},
+		{name: "single byte", size: 1},
+		{name: "below chunk", size: encryptChunk - 1},
+		{name: "exact chunk", size: encryptChunk},
+		{name: "above chunk", size: encryptChunk + 1},
+		{name: "multiple chunks", size: 3*encryptChunk + 17},
+	}
+
+	for _, tt := range tests {
+		for _, known := range []bool{true, false} {
+			name := tt.name
+
+			if !known {
+				name += " streamed"
+			}
+
+			t.Run(name, func(t *testing.T) {
+				ctx := context.Background()
+				backend, dir := testFileUpload(t)
+				u := testEncryptedUpload(t, backend, []string{testKey(t, "current")}, false)
+				content := testContent(t, tt.size)
+
+				size := int64(tt.size)
+
+				if !known {
+					size = -1
+				}
+
+				require.NoError(t, u.Upload(ctx, "file.txt", bytes.NewReader(content), size, "text/plain"))
+
+				info, err := os.Stat(filepath.Join(dir, "file.txt"))
+				require.NoError(t, err)
+				assert.Equal(t, encryptedSize(int64(tt.size)), info.Size())
+
+				stored, err := os.ReadFile(filepath.Join(dir, "file.txt"))
+				require.NoError(t, err)
+				assert.True(t, bytes.HasPrefix(stored, []byte(encryptMagic)))
+
+				if tt.size >= encryptChunk {
+					assert.False(t, bytes.Contains(stored, content))
+				}
+
+				object, err := u.Stat(ctx, "file.txt")
+				require.NoError(t, err)
+				assert.Equal(t, int64(tt.size), object.Size)
+
+				objects, err := u.List(ctx, "")
+				require.NoError(t, err)
+				require.Len(t, objects, 1)
+				assert.Equal(t, int64(tt.size), objects[0].Size)
+
+				reader, err := u.Open(ctx, "file.txt")
+				require.NoError(t, err)
+
+				defer func() { _ = reader.Close() }()
+
+				result, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				assert.Equal(t, content, result)
+			})
+		}
+	}
+}
+
+func TestPlainSize(t *testing.T) {
+	for _, size := range []int64{0, 1, encryptChunk - 1, encryptChunk, encryptChunk + 1, 5 * encryptChunk, 5*encryptChunk + 3} {
+		assert.Equal(t, size, plainSize(encryptedSize(size)), size)
+	}
+}
+
+func TestEncryptListWithoutOpen(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	backend := NewMockUpload(ctrl)
+
+	backend.EXPECT().
+		List(gomock.Any(), "avatars/").
+		Return([]*Object{
+			{Key: "avatars/a.png", Size: encryptedSize(10)},
+			{Key: "avatars/b.png", Size: encryptedSize(encryptChunk * 2)},
+		}, nil)
+
+	u := testEncryptedUpload(t, backend, []string{testKey(t, "current")}, false)
+	objects, err := u.List(context.Background(), "avatars/")
+
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	assert.Equal(t, int64(10), objects[0].Size)
+	assert.Equal(t, int64(encryptChunk*2), objects[1].Size)
+}
+
+func TestEncryptPlaintext(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := testFileUpload(t)
+	keys := []string{testKey(t, "current")}
+	content := "stored before encryption"
+
+	require.NoError(t, backend.Upload(ctx, "plain.txt", strings.NewReader(content), int64(len(content)), "text/plain"))
+
+	tests := []struct {
+		name      string
+		plaintext bool
+		err       error
+	}{
+		{name: "rejected", plaintext: false, err: ErrUnencrypted},
+		{name: "migration", plaintext: true, err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := testEncryptedUpload(t, backend, keys, tt.plaintext)
+			reader, err := u.Open(ctx, "plain.txt")
+			require.NoError(t, err)
+
+			defer func() { _ = reader.Close() }()
+
+			result, err := io.ReadAll(reader)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, content, string(result))
+
+			object, err := u.Stat(ctx, "plain.txt")
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(content)), object.Size)
+		})
+	}
+
+	u := testEncryptedUpload(t, backend, keys, false)
+	changed, err := u.Reencrypt(ctx, "plain.txt", false)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	reader, err := u.Open(ctx, "plain.txt")
+	require.NoError(t, err)
+
+	defer func() { _ = reader.Close() }()
+
+	result, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(result))
+}
+
+func TestEncryptKeys(t *testing.T) {
+	ctx := context.Background()
+	backend, dir := testFileUpload(t)
+	previous := testKey(t, "previous")
+	current := testKey(t, "current")
+	content := testContent(t, encryptChunk+5)
+
+	u := testEncryptedUpload(t, backend, []string{previous}, false)
+	require.NoError(t, u.Upload(ctx, "file.bin", bytes.NewReader(content), int64(len(content)), ""))
+
+	tests := []struct {
+		name     string
+		keys     []string
+		truncate bool
+		err      error
+	}{
+		{name: "rotated", keys: []string{current, previous}, err: nil},
+		{name: "unknown key", keys: []string{current}, err: ErrUnknownKey},
+		{name: "truncated", keys: []string{previous}, truncate: true, err: ErrCorrupted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.truncate {
+				require.NoError(t, os.Truncate(filepath.Join(dir, "file.bin"), encryptedSize(encryptChunk)))
+			}
+
+			u := testEncryptedUpload(t, backend, tt.keys, false)
+			reader, err := u.Open(ctx, "file.bin")
+			require.NoError(t, err)
+
+			defer func() { _ = reader.Close() }()
+
+			result, err := io.ReadAll(reader)
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, content, result)
+		})
+	}
+}
+
+func TestEncryptSeek(t *testing.T) {
+	ctx := context.Background()
+	backend, _ := testFileUpload(t)
+	content := testContent(t, 5*encryptChunk+17)
+	keys := []string{testKey(t, "current")}
+
+	require.NoError(t, testEncryptedUpload(t, backend, keys, false).Upload(ctx, "file.bin", bytes.NewReader(content), int64(len(content)), ""))
+	require.NoError(t, backend.Upload(ctx, "plain.bin", bytes.NewReader(content), int64(len(content)), ""))
+
+	tests := []struct {
+		name   string
+		key    string
+		offset int64
+		ranged bool
+	}{
+		{name: "start", key: "file.bin", offset: 0, ranged: true},
+		{name: "within first chunk", key: "file.bin", offset: 10, ranged: true},
+		{name: "chunk boundary", key: "file.bin", offset: 2 * encryptChunk, ranged: true},
+		{name: "last chunk", key: "file.bin", offset: 5*encryptChunk + 7, ranged: true},
+		{name: "without range support", key: "file.bin", offset: 3*encryptChunk + 5, ranged: false},
+		{name: "plaintext", key: "plain.bin", offset: 4*encryptChunk + 3, ranged: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := &countUpload{backendUpload: backend}
+			wrapped := Upload(counter)
+
+			if !tt.ranged {
+				wrapped = struct{ backendUpload }{counter}
+			}
+
+			u := testEncryptedUpload(t, wrapped, keys, true)
+
+			reader := &decryptSeeker{
+				ctx:    ctx,
+				upload: u,
+				key:    tt.key,
+				size:   int64(len(content)),
+			}
+
+			defer func() { _ = reader.Close() }()
+
+			_, err := reader.Seek(tt.offset, io.SeekStart)
+			require.NoError(t, err)
+
+			result := make([]byte, 10)
+			_, err = io.ReadFull(reader, result)
+			require.NoError(t, err)
+			assert.Equal(t, content[tt.offset:tt.offset+10], result)
+
+			if tt.ranged {
+				assert.LessOrEqual(t, counter.read, int64(2*encryptHeader+encryptChunk+encryptTag))
+			}
+		})
+	}
+}
+
+func testFileUpload(t *testing.T) (Upload, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	backend, err := NewFileUpload(config.Upload{
+		Path: dir,
+	})
+
+	require.NoError(t, err)
+
+	_, err = backend.Prepare()
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = backend.Close()
+	})
+
+	return backend, dir
+}
+
+func testEncryptedUpload(t *testing.T, backend Upload, keys []string, plaintext bool) *EncryptedUpload {
+	t.Helper()
+
+	u, err := NewEncryptedUpload(backend, keys, plaintext)
+	require.NoError(t, err)
+
+	return u.(*EncryptedUpload)
+}
+
+func testKey(t *testing.T, id string) string {
+	t.Helper()
+
+	secret := make([]byte, encryptDEK)
+	_, err := rand.Read(secret)
+	require.NoError(t, err)
+
+	return id + ":base64://" + base64.StdEncoding.EncodeToString(secret)
+}
+
+func testContent(t *testing.T, size int) []byte {
+	t.Helper()
+
+	content := make([]byte, size)
+	_, err := rand.Read(content)
+	require.NoError(t, err)
+
+	return content
+}
+
+// backendUpload allows to embed an Upload without hiding its Upload method.
+type backendUpload = Upload
+
+// countUpload counts the bytes read through ranged reads of the backend.
+type countUpload struct {
+	backendUpload
+	read int64
+}
+
+func (u *countUpload) OpenRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	body, err := u.backendUpload.(RangeUpload).OpenRange(ctx, key, offset, length)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: &countReader{reader: body, count: &u.read},
+		Closer: body,
+	}, nil
+}
+
+type countReader struct {
+	reader io.Reader
+	count  *int64
+}
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	*r.count += int64(n)
+
+	return n, err
+}
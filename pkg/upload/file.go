@@ -1,10 +1,12 @@
 package upload
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"io/fs"
+	"mime"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -56,8 +58,8 @@ func (u *FileUpload) Close() error {
 	return u.root.Close()
 }
 
-// Upload stores an attachment within the defined S3 bucket.
-func (u *FileUpload) Upload(_ context.Context, path string, content *bytes.Buffer) error {
+// Upload streams an attachment into the defined path.
+func (u *FileUpload) Upload(_ context.Context, path string, content io.Reader, size int64, _ string) error {
 	parent := filepath.Dir(
 		path,
 	)
@@ -84,11 +86,21 @@ func (u *FileUpload) Upload(_ context.Context, path string, content *bytes.Buffe
 		return err
 	}
 
-	defer func() { _ = file.Close() }()
+	written, err := io.Copy(
+		file,
+		content,
+	)
+
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
 
-	if _, err = file.Write(
-		content.Bytes(),
-	); err != nil {
+	if err == nil && size >= 0 && written != size {
+		err = ErrSizeMismatch
+	}
+
+	if err != nil {
+		_ = u.root.Remove(path)
 		return err
 	}
 
@@ -118,19 +130,106 @@ func (u *FileUpload) Delete(_ context.Context, path string, recursive bool) erro
 	return u.root.Remove(path)
 }
 
+// List returns all attachments stored below the defined prefix.
+func (u *FileUpload) List(_ context.Context, prefix string) ([]*Object, error) {
+	result := make([]*Object, 0)
+
+	if err := fs.WalkDir(u.root.FS(), ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() || !strings.HasPrefix(path, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+
+		if err != nil {
+			return err
+		}
+
+		result = append(result, &Object{
+			Key:     path,
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+		})
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// Stat returns the metadata of an attachment from the defined path.
+func (u *FileUpload) Stat(_ context.Context, path string) (*Object, error) {
+	info, err := u.root.Stat(path)
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return nil, ErrNotFound
+	}
+
+	return &Object{
+		Key:         path,
+		Size:        info.Size(),
+		ModTime:     info.ModTime(),
+		ContentType: mime.TypeByExtension(filepath.Ext(path)),
+		ETag:        fmt.Sprintf(`"%x-%x"`, info.ModTime().UnixNano(), info.Size()),
+	}, nil
+}
+
+// Open provides a reader for an attachment from the defined path.
+func (u *FileUpload) Open(_ context.Context, path string) (io.ReadCloser, error) {
+	return u.root.Open(path)
+}
+
+// OpenRange provides a reader for a range of an attachment from the defined path.
+func (u *FileUpload) OpenRange(_ context.Context, path string, offset, length int64) (io.ReadCloser, error) {
+	file, err := u.root.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return limitRange(file, length), nil
+}
+
 // Handler implements an HTTP handler for asset uploads.
 func (u *FileUpload) Handler(root string) http.Handler {
 	if !strings.HasSuffix(root, "/") {
 		root = root + "/"
 	}
 
+	files := http.FileServer(
+		http.FS(
+			u.root.FS(),
+		),
+	)
+
 	return http.StripPrefix(
 		root,
-		http.FileServer(
-			http.FS(
-				u.root.FS(),
-			),
-		),
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if obj, err := u.Stat(r.Context(), r.URL.Path); err == nil {
+				w.Header().Set("ETag", obj.ETag)
+			}
+
+			files.ServeHTTP(w, r)
+		}),
 	)
 }
 
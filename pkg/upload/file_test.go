@@ -0,0 +1,10 @@
+package upload
+
+import (
+	"testing"
+)
+
+func TestFileUpload(t *testing.T) {
+	backend, _ := testFileUpload(t)
+	testDriver(t, backend)
+}
@@ -0,0 +1,389 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"github.com/gopad/gopad-api/pkg/config"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// GCSUpload implements the Upload interface.
+type GCSUpload struct {
+	endpoint string
+	path     string
+	secret   string
+	bucket   string
+	proxy    bool
+
+	client *storage.Client
+	handle *storage.BucketHandle
+}
+
+// Info prepares some informational message about the handler.
+func (u *GCSUpload) Info() map[string]interface{} {
+	result := make(map[string]interface{})
+	result["driver"] = "gcs"
+	result["endpoint"] = u.endpoint
+	result["path"] = u.path
+	result["bucket"] = u.bucket
+	result["proxy"] = u.proxy
+
+	return result
+}
+
+// Prepare simply prepares the upload handler.
+func (u *GCSUpload) Prepare() (Upload, error) {
+	opts := make([]option.ClientOption, 0)
+
+	// Custom endpoints like emulators don't reliably implement the XML API,
+	// that's why reads are using the JSON API in this case.
+	if u.endpoint != "" {
+		opts = append(
+			opts,
+			option.WithEndpoint(u.endpoint),
+			storage.WithJSONReads(),
+		)
+	}
+
+	switch {
+	case u.secret != "":
+		secret, err := config.Value(u.secret)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse secret key: %w", err)
+		}
+
+		opts = append(
+			opts,
+			option.WithCredentialsJSON([]byte(secret)),
+		)
+	case u.endpoint != "":
+		opts = append(
+			opts,
+			option.WithoutAuthentication(),
+		)
+	}
+
+	client, err := storage.NewClient(
+		context.Background(),
+		opts...,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	u.client = client
+	u.handle = client.Bucket(u.bucket)
+
+	return u, nil
+}
+
+// Close simply closes the upload handler.
+func (u *GCSUpload) Close() error {
+	if u.client == nil {
+		return nil
+	}
+
+	return u.client.Close()
+}
+
+// Upload streams an attachment into the defined GCS bucket.
+func (u *GCSUpload) Upload(ctx context.Context, key string, content io.Reader, size int64, ctype string) error {
+	if ctype == "" {
+		detected, reader, err := sniffUpload(content)
+
+		if err != nil {
+			return err
+		}
+
+		ctype = detected
+		content = reader
+	}
+
+	obj := u.handle.Object(
+		path.Join(u.path, key),
+	)
+
+	// Canceling the context is the only way to abort a writer, closing it
+	// would commit the partially written object.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	writer := obj.NewWriter(ctx)
+	writer.ContentType = ctype
+
+	if _, err := io.Copy(writer, &sizeReader{
+		reader: content,
+		size:   size,
+	}); err != nil {
+		cancel()
+		_ = writer.Close()
+
+		return err
+	}
+
+	return writer.Close()
+}
+
+// Delete removes an attachment from the defined GCS bucket.
+func (u *GCSUpload) Delete(ctx context.Context, key string, recursive bool) error {
+	if recursive {
+		objects, err := u.List(ctx, key)
+
+		if err != nil {
+			return err
+		}
+
+		for _, object := range objects {
+			if err := u.handle.Object(
+				path.Join(u.path, object.Key),
+			).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+				return fmt.Errorf("failed to delete objects: %w", err)
+			}
+		}
+
+		return nil
+	}
+
+	if err := u.handle.Object(
+		path.Join(u.path, key),
+	).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// List returns all attachments stored below the defined prefix.
+func (u *GCSUpload) List(ctx context.Context, prefix string) ([]*Object, error) {
+	result := make([]*Object, 0)
+
+	objects := u.handle.Objects(
+		ctx,
+		&storage.Query{
+			Prefix: strings.TrimPrefix(path.Join(u.path, prefix), "/"),
+		},
+	)
+
+	for {
+		object, err := objects.Next()
+
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		result = append(result, &Object{
+			Key: strings.TrimPrefix(
+				strings.TrimPrefix(
+					object.Name,
+					u.path,
+				),
+				"/",
+			),
+			Size:        object.Size,
+			ModTime:     object.Updated,
+			ContentType: object.ContentType,
+			ETag:        fmt.Sprintf("%q", object.Etag),
+		})
+	}
+
+	return result, nil
+}
+
+// Stat returns the metadata of an attachment from the defined GCS bucket.
+func (u *GCSUpload) Stat(ctx context.Context, key string) (*Object, error) {
+	attrs, err := u.handle.Object(
+		path.Join(u.path, key),
+	).Attrs(ctx)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &Object{
+		Key:         key,
+		Size:        attrs.Size,
+		ModTime:     attrs.Updated,
+		ContentType: attrs.ContentType,
+		ETag:        fmt.Sprintf("%q", attrs.Etag),
+	}, nil
+}
+
+// Open provides a reader for an attachment from the defined GCS bucket.
+func (u *GCSUpload) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	reader, err := u.handle.Object(
+		path.Join(u.path, key),
+	).NewReader(ctx)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+// OpenRange provides a reader for a range of an attachment from the defined GCS bucket.
+func (u *GCSUpload) OpenRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	reader, err := u.handle.Object(
+		path.Join(u.path, key),
+	).NewRangeReader(
+		ctx,
+		offset,
+		length,
+	)
+
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+// Handler implements an HTTP handler for asset uploads.
+func (u *GCSUpload) Handler(root string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if u.proxy {
+			u.proxyHandler(root, w, r)
+		} else {
+			u.presignHandler(root, w, r)
+		}
+	})
+}
+
+func (u *GCSUpload) proxyHandler(root string, w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(
+		path.Join(
+			"/",
+			strings.TrimPrefix(
+				r.URL.Path,
+				root,
+			),
+		),
+		"/",
+	)
+
+	obj, err := u.Stat(
+		r.Context(),
+		key,
+	)
+
+	if err != nil {
+		http.Error(
+			w,
+			http.StatusText(http.StatusNotFound),
+			http.StatusNotFound,
+		)
+
+		return
+	}
+
+	if obj.ContentType != "" {
+		w.Header().Set("Content-Type", obj.ContentType)
+	}
+
+	if obj.ETag != "" {
+		w.Header().Set("ETag", obj.ETag)
+	}
+
+	reader := &rangeReader{
+		ctx:  r.Context(),
+		size: obj.Size,
+		open: func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+			return u.OpenRange(ctx, key, offset, -1)
+		},
+	}
+
+	defer func() { _ = reader.Close() }()
+
+	http.ServeContent(
+		w,
+		r,
+		key,
+		obj.ModTime,
+		reader,
+	)
+}
+
+func (u *GCSUpload) presignHandler(root string, w http.ResponseWriter, r *http.Request) {
+	obj := strings.TrimPrefix(
+		path.Join(
+			u.path,
+			strings.TrimPrefix(
+				r.URL.Path,
+				root,
+			),
+		),
+		"/",
+	)
+
+	signed, err := u.handle.SignedURL(
+		obj,
+		&storage.SignedURLOptions{
+			Method:  http.MethodGet,
+			Scheme:  storage.SigningSchemeV4,
+			Expires: time.Now().Add(5 * time.Minute),
+		},
+	)
+
+	if err != nil {
+		http.Error(
+			w,
+			http.StatusText(http.StatusNotFound),
+			http.StatusNotFound,
+		)
+
+		return
+	}
+
+	http.Redirect(w, r, signed, http.StatusTemporaryRedirect)
+}
+
+// NewGCSUpload initializes a new GCS handler.
+func NewGCSUpload(cfg config.Upload) (Upload, error) {
+	f := &GCSUpload{
+		endpoint: cfg.Endpoint,
+		path:     cfg.Path,
+		secret:   cfg.Secret,
+		bucket:   cfg.Bucket,
+		proxy:    cfg.Proxy,
+	}
+
+	return f.Prepare()
+}
+
+// MustGCSUpload simply calls NewGCSUpload and panics on an error.
+func MustGCSUpload(cfg config.Upload) Upload {
+	db, err := NewGCSUpload(cfg)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return db
+}
@@ -0,0 +1,47 @@
+package upload
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/gopad/gopad-api/pkg/config"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+)
+
+func TestGCSUpload(t *testing.T) {
+	endpoint := os.Getenv("GOPAD_API_TEST_GCS_ENDPOINT")
+
+	if endpoint == "" {
+		t.Skip("GOPAD_API_TEST_GCS_ENDPOINT is not defined, e.g. http://127.0.0.1:4443/storage/v1/")
+	}
+
+	backend, err := NewGCSUpload(config.Upload{
+		Endpoint: endpoint,
+		Bucket:   "gopad",
+		Path:     "tests",
+	})
+
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = backend.Close()
+	})
+
+	if err := backend.(*GCSUpload).handle.Create(
+		context.Background(),
+		"gopad",
+		nil,
+	); err != nil {
+		var gerr *googleapi.Error
+
+		if !errors.As(err, &gerr) || gerr.Code != http.StatusConflict {
+			require.NoError(t, err)
+		}
+	}
+
+	testDriver(t, backend)
+}
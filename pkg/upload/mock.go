@@ -5,8 +5,8 @@
 package upload
 
 import (
-	bytes "bytes"
 	context "context"
+	io "io"
 	http "net/http"
 	reflect "reflect"
 
@@ -92,6 +92,36 @@ func (mr *MockUploadMockRecorder) Info() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockUpload)(nil).Info))
 }
 
+// List mocks base method.
+func (m *MockUpload) List(arg0 context.Context, arg1 string) ([]*Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].([]*Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockUploadMockRecorder) List(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockUpload)(nil).List), arg0, arg1)
+}
+
+// Open mocks base method.
+func (m *MockUpload) Open(arg0 context.Context, arg1 string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", arg0, arg1)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockUploadMockRecorder) Open(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockUpload)(nil).Open), arg0, arg1)
+}
+
 // Prepare mocks base method.
 func (m *MockUpload) Prepare() (Upload, error) {
 	m.ctrl.T.Helper()
@@ -107,16 +137,69 @@ func (mr *MockUploadMockRecorder) Prepare() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prepare", reflect.TypeOf((*MockUpload)(nil).Prepare))
 }
 
+// Stat mocks base method.
+func (m *MockUpload) Stat(arg0 context.Context, arg1 string) (*Object, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stat", arg0, arg1)
+	ret0, _ := ret[0].(*Object)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stat indicates an expected call of Stat.
+func (mr *MockUploadMockRecorder) Stat(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stat", reflect.TypeOf((*MockUpload)(nil).Stat), arg0, arg1)
+}
+
 // Upload mocks base method.
-func (m *MockUpload) Upload(arg0 context.Context, arg1 string, arg2 *bytes.Buffer) error {
+func (m *MockUpload) Upload(arg0 context.Context, arg1 string, arg2 io.Reader, arg3 int64, arg4 string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Upload", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "Upload", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Upload indicates an expected call of Upload.
-func (mr *MockUploadMockRecorder) Upload(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockUploadMockRecorder) Upload(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockUpload)(nil).Upload), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MockRangeUpload is a mock of RangeUpload interface.
+type MockRangeUpload struct {
+	ctrl     *gomock.Controller
+	recorder *MockRangeUploadMockRecorder
+}
+
+// MockRangeUploadMockRecorder is the mock recorder for MockRangeUpload.
+type MockRangeUploadMockRecorder struct {
+	mock *MockRangeUpload
+}
+
+// NewMockRangeUpload creates a new mock instance.
+func NewMockRangeUpload(ctrl *gomock.Controller) *MockRangeUpload {
+	mock := &MockRangeUpload{ctrl: ctrl}
+	mock.recorder = &MockRangeUploadMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRangeUpload) EXPECT() *MockRangeUploadMockRecorder {
+	return m.recorder
+}
+
+// OpenRange mocks base method.
+func (m *MockRangeUpload) OpenRange(arg0 context.Context, arg1 string, arg2, arg3 int64) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OpenRange", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OpenRange indicates an expected call of OpenRange.
+func (mr *MockRangeUploadMockRecorder) OpenRange(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockUpload)(nil).Upload), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OpenRange", reflect.TypeOf((*MockRangeUpload)(nil).OpenRange), arg0, arg1, arg2, arg3)
 }
@@ -0,0 +1,135 @@
+package upload
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// rangeReader lazily opens an object starting at the current offset, this
+// allows http.ServeContent to serve ranges without reading the whole object.
+type rangeReader struct {
+	ctx    context.Context
+	size   int64
+	offset int64
+	body   io.ReadCloser
+	open   func(context.Context, int64) (io.ReadCloser, error)
+}
+
+// Read implements the io.Reader interface.
+func (r *rangeReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.body == nil {
+		body, err := r.open(r.ctx, r.offset)
+
+		if err != nil {
+			return 0, err
+		}
+
+		r.body = body
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+
+	return n, err
+}
+
+// Seek implements the io.Seeker interface.
+func (r *rangeReader) Seek(offset int64, whence int) (int64, error) {
+	target := offset
+
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		target += r.offset
+	case io.SeekEnd:
+		target += r.size
+	default:
+		return 0, fmt.Errorf("invalid seek whence")
+	}
+
+	if target < 0 {
+		return 0, fmt.Errorf("negative seek position")
+	}
+
+	if target != r.offset && r.body != nil {
+		_ = r.body.Close()
+		r.body = nil
+	}
+
+	r.offset = target
+	return target, nil
+}
+
+// Close implements the io.Closer interface.
+func (r *rangeReader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+
+	return r.body.Close()
+}
+
+// limitRange restricts a reader to the requested length, a negative length
+// keeps the reader unrestricted.
+func limitRange(body io.ReadCloser, length int64) io.ReadCloser {
+	if length < 0 {
+		return body
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.LimitReader(body, length),
+		Closer: body,
+	}
+}
+
+// sizeReader verifies the size of streamed uploads, reading fails instead
+// of returning EOF if the content is shorter or longer than the defined
+// size. That way drivers abort the upload before an object gets committed.
+type sizeReader struct {
+	reader io.Reader
+	size   int64
+	count  int64
+}
+
+// Read implements the io.Reader interface.
+func (r *sizeReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.count += int64(n)
+
+	if r.size < 0 {
+		return n, err
+	}
+
+	if r.count > r.size || (err == io.EOF && r.count != r.size) {
+		return n, ErrSizeMismatch
+	}
+
+	return n, err
+}
+
+// sniffUpload detects the content type from the head of a reader and
+// returns a reader which still provides the whole content.
+func sniffUpload(content io.Reader) (string, io.Reader, error) {
+	head := make([]byte, 3072)
+	n, err := io.ReadFull(content, head)
+
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", nil, err
+	}
+
+	return mimetype.Detect(head[:n]).String(), io.MultiReader(
+		bytes.NewReader(head[:n]),
+		content,
+	), nil
+}
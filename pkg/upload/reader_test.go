@@ -0,0 +1,37 @@
+package upload
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSizeReader(t *testing.T) {
+	tests := []struct {
+		name string
+		size int64
+		err  error
+	}{
+		{name: "exact", size: 7, err: nil},
+		{name: "unknown", size: -1, err: nil},
+		{name: "shorter", size: 8, err: ErrSizeMismatch},
+		{name: "longer", size: 6, err: ErrSizeMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := io.ReadAll(&sizeReader{
+				reader: strings.NewReader("content"),
+				size:   tt.size,
+			})
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,12 +1,13 @@
 package upload
 
 import (
-	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"os"
 	"path"
 	"strings"
 	"time"
@@ -147,26 +148,70 @@ func (u *S3Upload) Close() error {
 	return nil
 }
 
-// Upload stores an attachment within the defined S3 bucket.
-func (u *S3Upload) Upload(ctx context.Context, key string, content *bytes.Buffer) error {
-	reader := bytes.NewReader(
-		content.Bytes(),
-	)
+// Upload streams an attachment into the defined S3 bucket. Readers which
+// are not seekable get spooled to a temporary file to avoid buffering them
+// within memory, the request signing requires a known content length.
+func (u *S3Upload) Upload(ctx context.Context, key string, content io.Reader, size int64, ctype string) error {
+	body, ok := content.(io.ReadSeeker)
 
-	mtype, err := mimetype.DetectReader(
-		reader,
-	)
+	if !ok {
+		spooled, err := spoolUpload(content)
+
+		if err != nil {
+			return err
+		}
+
+		defer func() {
+			_ = spooled.Close()
+			_ = os.Remove(spooled.Name())
+		}()
+
+		body = spooled
+	}
+
+	start, err := body.Seek(0, io.SeekCurrent)
+
+	if err != nil {
+		return err
+	}
+
+	end, err := body.Seek(0, io.SeekEnd)
 
 	if err != nil {
 		return err
 	}
 
+	if size >= 0 && end-start != size {
+		return ErrSizeMismatch
+	}
+
+	if _, err := body.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
+
+	if ctype == "" {
+		mtype, err := mimetype.DetectReader(
+			body,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		if _, err := body.Seek(start, io.SeekStart); err != nil {
+			return err
+		}
+
+		ctype = mtype.String()
+	}
+
 	params := &s3.PutObjectInput{
-		ACL:         types.ObjectCannedACLPublicRead,
-		Bucket:      aws.String(u.bucket),
-		Key:         aws.String(path.Join(u.path, key)),
-		ContentType: aws.String(mtype.String()),
-		Body:        reader,
+		ACL:           types.ObjectCannedACLPublicRead,
+		Bucket:        aws.String(u.bucket),
+		Key:           aws.String(path.Join(u.path, key)),
+		ContentType:   aws.String(ctype),
+		ContentLength: aws.Int64(end - start),
+		Body:          body,
 	}
 
 	if _, err := u.client.PutObject(
@@ -250,6 +295,121 @@ func (u *S3Upload) Delete(ctx context.Context, key string, recursive bool) error
 	return nil
 }
 
+// List returns all attachments stored below the defined prefix.
+func (u *S3Upload) List(ctx context.Context, prefix string) ([]*Object, error) {
+	var (
+		continuation *string
+	)
+
+	result := make([]*Object, 0)
+
+	for {
+		objects, err := u.client.ListObjectsV2(
+			ctx, &s3.ListObjectsV2Input{
+				Bucket:            aws.String(u.bucket),
+				Prefix:            aws.String(path.Join(u.path, prefix)),
+				ContinuationToken: continuation,
+			},
+		)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		for _, object := range objects.Contents {
+			result = append(result, &Object{
+				Key: strings.TrimPrefix(
+					strings.TrimPrefix(
+						aws.ToString(object.Key),
+						u.path,
+					),
+					"/",
+				),
+				Size:    aws.ToInt64(object.Size),
+				ModTime: aws.ToTime(object.LastModified),
+			})
+		}
+
+		if !aws.ToBool(objects.IsTruncated) {
+			break
+		}
+
+		continuation = objects.NextContinuationToken
+	}
+
+	return result, nil
+}
+
+// Stat returns the metadata of an attachment from the defined S3 bucket.
+func (u *S3Upload) Stat(ctx context.Context, key string) (*Object, error) {
+	head, err := u.client.HeadObject(
+		ctx,
+		&s3.HeadObjectInput{
+			Bucket: aws.String(u.bucket),
+			Key:    aws.String(path.Join(u.path, key)),
+		},
+	)
+
+	if err != nil {
+		var notFound *types.NotFound
+
+		if errors.As(err, &notFound) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return &Object{
+		Key:         key,
+		Size:        aws.ToInt64(head.ContentLength),
+		ModTime:     aws.ToTime(head.LastModified),
+		ContentType: aws.ToString(head.ContentType),
+		ETag:        aws.ToString(head.ETag),
+	}, nil
+}
+
+// Open provides a reader for an attachment from the defined S3 bucket.
+func (u *S3Upload) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	obj, err := u.client.GetObject(
+		ctx,
+		&s3.GetObjectInput{
+			Bucket: aws.String(u.bucket),
+			Key:    aws.String(path.Join(u.path, key)),
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.Body, nil
+}
+
+// OpenRange provides a reader for a range of an attachment from the defined S3 bucket.
+func (u *S3Upload) OpenRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error) {
+	rng := fmt.Sprintf("bytes=%d-", offset)
+
+	if length >= 0 {
+		rng = fmt.Sprintf("bytes=%d-%d", offset, offset+length-1)
+	}
+
+	obj, err := u.client.GetObject(
+		ctx,
+		&s3.GetObjectInput{
+			Bucket: aws.String(u.bucket),
+			Key:    aws.String(path.Join(u.path, key)),
+			Range:  aws.String(rng),
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.Body, nil
+}
+
 // Handler implements an HTTP handler for asset uploads.
 func (u *S3Upload) Handler(root string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -262,9 +422,9 @@ func (u *S3Upload) Handler(root string) http.Handler {
 }
 
 func (u *S3Upload) proxyHandler(root string, w http.ResponseWriter, r *http.Request) {
-	obj := strings.TrimPrefix(
+	key := strings.TrimPrefix(
 		path.Join(
-			u.path,
+			"/",
 			strings.TrimPrefix(
 				r.URL.Path,
 				root,
@@ -273,12 +433,9 @@ func (u *S3Upload) proxyHandler(root string, w http.ResponseWriter, r *http.Requ
 		"/",
 	)
 
-	req, err := u.client.GetObject(
+	obj, err := u.Stat(
 		r.Context(),
-		&s3.GetObjectInput{
-			Bucket: aws.String(u.bucket),
-			Key:    aws.String(obj),
-		},
+		key,
 	)
 
 	if err != nil {
@@ -291,32 +448,30 @@ func (u *S3Upload) proxyHandler(root string, w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	defer func() { _ = req.Body.Close() }()
-
-	modified := time.Now()
-
-	if req.LastModified != nil {
-		modified = *req.LastModified
+	if obj.ContentType != "" {
+		w.Header().Set("Content-Type", obj.ContentType)
 	}
 
-	buffer, err := io.ReadAll(req.Body)
-
-	if err != nil {
-		http.Error(
-			w,
-			"Failed to read buffer",
-			http.StatusInternalServerError,
-		)
+	if obj.ETag != "" {
+		w.Header().Set("ETag", obj.ETag)
+	}
 
-		return
+	reader := &rangeReader{
+		ctx:  r.Context(),
+		size: obj.Size,
+		open: func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+			return u.OpenRange(ctx, key, offset, -1)
+		},
 	}
 
+	defer func() { _ = reader.Close() }()
+
 	http.ServeContent(
 		w,
 		r,
-		obj,
-		modified,
-		bytes.NewReader(buffer),
+		key,
+		obj.ModTime,
+		reader,
 	)
 }
 
@@ -383,6 +538,30 @@ func MustS3Upload(cfg config.Upload) Upload {
 	return db
 }
 
+func spoolUpload(content io.Reader) (*os.File, error) {
+	file, err := os.CreateTemp("", "gopad-upload-*")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := io.Copy(file, content); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+
+		return nil, err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
 // CustomEndpointResolver is used for S3 compatible storage endpoints.
 type CustomEndpointResolver struct {
 	Endpoint  *url.URL
@@ -1,10 +1,11 @@
 package upload
 
 import (
-	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 )
 
 //go:generate go tool github.com/golang/mock/mockgen -source upload.go -destination mock.go -package upload
@@ -12,14 +13,39 @@ import (
 var (
 	// ErrUnknownDriver defines a named error for unknown upload drivers.
 	ErrUnknownDriver = fmt.Errorf("unknown upload driver")
+
+	// ErrNotFound defines a named error for missing uploads.
+	ErrNotFound = fmt.Errorf("upload not found")
+
+	// ErrSizeMismatch defines a named error for uploads with unexpected size.
+	ErrSizeMismatch = fmt.Errorf("upload size mismatch")
 )
 
-// Upload provides the interface for the upload implementations.
+// Object defines the metadata of a stored upload.
+type Object struct {
+	Key         string
+	Size        int64
+	ModTime     time.Time
+	ContentType string
+	ETag        string
+}
+
+// Upload provides the interface for the upload implementations. Uploads are
+// streamed with their size, or -1 if unknown, and an optional content type.
 type Upload interface {
 	Info() map[string]interface{}
 	Prepare() (Upload, error)
 	Close() error
-	Upload(context.Context, string, *bytes.Buffer) error
+	Upload(context.Context, string, io.Reader, int64, string) error
 	Delete(context.Context, string, bool) error
+	List(context.Context, string) ([]*Object, error)
+	Stat(context.Context, string) (*Object, error)
+	Open(context.Context, string) (io.ReadCloser, error)
 	Handler(string) http.Handler
 }
+
+// RangeUpload is implemented by drivers which are able to open a range of an
+// attachment, a negative length reads until the end of the attachment.
+type RangeUpload interface {
+	OpenRange(context.Context, string, int64, int64) (io.ReadCloser, error)
+}
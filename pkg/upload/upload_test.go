@@ -0,0 +1,83 @@
+package upload
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDriver runs the behavior every upload driver has to provide, it's
+// shared between the file driver and the drivers running against emulators.
+func testDriver(t *testing.T, u Upload) {
+	t.Helper()
+
+	ctx := context.Background()
+	content := "some attachment content"
+
+	t.Cleanup(func() {
+		_ = u.Delete(context.Background(), "driver", true)
+	})
+
+	tests := []struct {
+		name string
+		key  string
+		size int64
+		err  error
+	}{
+		{name: "known size", key: "driver/known.txt", size: int64(len(content))},
+		{name: "unknown size", key: "driver/unknown.txt", size: -1},
+		{name: "too short", key: "driver/short.txt", size: int64(len(content)) + 5, err: ErrSizeMismatch},
+		{name: "too long", key: "driver/long.txt", size: int64(len(content)) - 5, err: ErrSizeMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := u.Upload(ctx, tt.key, strings.NewReader(content), tt.size, "text/plain")
+
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+
+				_, err := u.Stat(ctx, tt.key)
+				assert.ErrorIs(t, err, ErrNotFound)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			object, err := u.Stat(ctx, tt.key)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(content)), object.Size)
+
+			reader, err := u.Open(ctx, tt.key)
+			require.NoError(t, err)
+
+			defer func() { _ = reader.Close() }()
+
+			result, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, content, string(result))
+		})
+	}
+
+	objects, err := u.List(ctx, "driver/")
+	require.NoError(t, err)
+
+	keys := make([]string, 0, len(objects))
+
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+
+	assert.ElementsMatch(t, []string{"driver/known.txt", "driver/unknown.txt"}, keys)
+
+	require.NoError(t, u.Delete(ctx, "driver", true))
+
+	objects, err = u.List(ctx, "driver/")
+	require.NoError(t, err)
+	assert.Empty(t, objects)
+}